@@ -0,0 +1,50 @@
+package analysis
+
+import "fmt"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+
+// Computes exact state space sizes of Engel puzzles with the permutation group module, instead of
+// exploring them.
+func AnalyzeEngelGroups() {
+
+	fmt.Println("Analyze Engel's puzzles as permutation groups:")
+
+	var wheels = &engel.EngelGame{}
+
+	wheels.Define([12]int{7, 6, 13, 14, 15, 16, 17, 18, 19, 20, 21, 8}, [12]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	wheels.DefineIntersectionPositions([3]int{11, 0, 1}, [3]int{7, 6, 5})
+
+	fmt.Printf("\n Group order (all pieces different): %v", wheels.Group().Order())
+
+	// Color Wheels
+	wheels.SetAlike([][]int{
+		[]int{1, 5, 9, 13, 17, 21},
+		[]int{2, 4},
+		[]int{6, 8},
+		[]int{10, 12},
+		[]int{14, 16},
+		[]int{18, 20},
+	})
+	fmt.Printf("\n COLOR WHEELS states: %v", wheels.OrbitSize())
+
+	// Sun-Moon
+	wheels.SetAlike([][]int{
+		[]int{1, 3, 5, 7, 9, 11},
+		[]int{2, 4, 6, 8, 10, 12},
+		[]int{13, 15, 17, 19, 21},
+		[]int{14, 16, 18, 20},
+	})
+	fmt.Printf("\n SUN-MOON states: %v", wheels.OrbitSize())
+
+	// Two pieces of the left wheel swapped, everything else in place
+	target := wheels.NewState([12]int{7, 6, 13, 14, 15, 16, 17, 18, 19, 20, 21, 8}, [12]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	wheels.SetAlike(nil)
+	swapped := wheels.NewState([12]int{7, 6, 15, 14, 13, 16, 17, 18, 19, 20, 21, 8}, [12]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+
+	solvable, err := wheels.Solvable(target)
+	fmt.Printf("\n Initial state solvable: %v %v", solvable, err)
+
+	solvable, err = wheels.Solvable(swapped)
+	fmt.Printf("\n Two swapped pieces solvable: %v %v\n", solvable, err)
+}
@@ -0,0 +1,61 @@
+package analysis
+
+import "fmt"
+import "math/rand"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/finder"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Scrambles Engel's SUN-MOON with random wheel turns and finds optimal solutions for them.
+func SolveEngelScrambles() {
+
+	fmt.Println("Solve Engel's SUN-MOON scrambles:")
+
+	sunMoon := puzzles.Lookup("SunMoon").NewEngelGame()
+
+	const (
+
+		// Max solution length
+		MAX_DEPTH = 30
+
+		// Max number of states stored by both searches. If 0, then ignored.
+		MAX_STATES = 1000000
+
+		NUM_SCRAMBLES = 5
+		SCRAMBLE_LEN  = 40
+	)
+
+	var solver finder.BidirectionalFinder
+
+	solver.SilentMode(true)
+	solver.SetLimits(MAX_DEPTH, MAX_STATES)
+
+	initial := sunMoon.State()
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < NUM_SCRAMBLES; i++ {
+
+		// Scramble with random turns, alternating wheels
+		for j := 0; j < SCRAMBLE_LEN; j++ {
+			sunMoon.Move(engel.NewEngelCommand(j%2, 1+rnd.Intn(5)))
+		}
+		scrambled := sunMoon.State().(*engel.EngelState)
+		sunMoon.SetState(initial)
+
+		fmt.Printf("\n Scramble %d: ", i+1)
+		scrambled.Print()
+
+		solution, err := solver.SolveEngel(sunMoon, scrambled)
+		if err != nil {
+			fmt.Printf("\n  Not solved: %v", err)
+			continue
+		}
+
+		fmt.Printf("\n  Solution (%d turns): ", len(solution))
+		for _, mov := range solution {
+			mov.Print()
+		}
+	}
+	fmt.Println("")
+}
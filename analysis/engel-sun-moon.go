@@ -3,30 +3,13 @@ package analysis
 import "fmt"
 
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/finder"
-import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
 
 func AnalyzeEngelSunMoon() {
 
 	fmt.Println("Analyze Engel's SUN-MOON:")
 
-	var colorWheels = &engel.EngelGame{}
-
-	// Define the piece ids for each wheel
-	colorWheels.Define([12]int{7, 6, 13, 14, 15, 16, 17, 18, 19, 20, 21, 8}, [12]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
-
-	colorWheels.DefineIntersectionPositions([3]int{11, 0, 1}, [3]int{7, 6, 5})
-
-	// Identify similar pieces
-	colorWheels.SetAlike([][]int{
-
-		// Right wheel is full colour, so there are two groups (rectangles and trias)
-		[]int{1, 3, 5, 7, 9, 11},
-		[]int{2, 4, 6, 8, 10, 12},
-
-		// Left wheel with remaining pieces:
-		[]int{13, 15, 17, 19, 21},
-		[]int{14, 16, 18, 20},
-	})
+	colorWheels := puzzles.Lookup("SunMoon").NewEngelGame()
 
 	const (
 
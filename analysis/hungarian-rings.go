@@ -0,0 +1,54 @@
+package analysis
+
+import "fmt"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/finder"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/rotational"
+
+// Two rings of 20 balls crossing at two points, five positions apart. Each ring turn moves its balls
+// one position, so each ring is a generator of order 20.
+func AnalyzeHungarianRings() {
+
+	fmt.Println("Analyze HUNGARIAN RINGS:")
+
+	var rings = &rotational.RotationalGame{}
+
+	// Ring A holds balls 1..20; it crosses ring B at balls 1 and 6.
+	ringA := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20}
+	ringB := []int{6, 21, 22, 23, 24, 1, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38}
+
+	if err := rings.DefineWheels([][]int{ringA, ringB}, []int{1, 1}); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	// Identify similar balls: each ring has two colors, crossings keep their own
+	rings.SetAlike([][]int{
+		[]int{2, 3, 4, 5, 7, 8, 9, 10, 11},
+		[]int{12, 13, 14, 15, 16, 17, 18, 19, 20},
+		[]int{21, 22, 23, 24, 25, 26, 27, 28, 29},
+		[]int{30, 31, 32, 33, 34, 35, 36, 37, 38},
+	})
+
+	const (
+
+		// Max depth reached by finder/solver
+		MAX_DEPTH = 30
+
+		// Max number of states to be processed. If 0, then ignored.
+		// Can be combined with MAX_DEPTH: if either of these two values is exceeded, the algorithm stops.
+		MAX_STATES = 1000000
+
+		// Enables/disables debug options (console output, etc.)
+		DEBUG = false
+	)
+
+	var analyzer finder.Analyzer
+
+	analyzer.SetDebug(DEBUG)
+	analyzer.SetLimits(MAX_DEPTH, MAX_STATES)
+
+	analyzer.Explore(rings)
+
+	analyzer.Resume()
+}
@@ -0,0 +1,56 @@
+package analysis
+
+import "fmt"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/pdb"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Builds pattern databases for Pennant (only the big piece distinct) and Color Wheels (only the right
+// wheel rectangles distinct) and saves them to disk.
+func BuildPatternDatabases() {
+
+	fmt.Println("Build pattern databases:")
+
+	const (
+
+		// Max number of abstract states. If 0, then ignored.
+		MAX_STATES = 5000000
+
+		PENNANT_FILE      = "pennant.pdb"
+		COLOR_WHEELS_FILE = "color-wheels.pdb"
+	)
+
+	// PENNANT
+	pennant := puzzles.Lookup("Pennant")
+	start, goal := pennant.Start, pennant.Goal
+
+	db, abstraction, err := pdb.BuildSBP(pennant.NewSBGame(), &goal, []int{2}, defs.MoveMetric(), MAX_STATES)
+	if err != nil {
+		fmt.Printf("\n Pennant database not built: %v", err)
+	} else {
+		d, _ := db.Distance(abstraction.Key(&start))
+		fmt.Printf("\n PENNANT: %d abstract states, max distance %d, start heuristic %d", db.Size(), db.MaxDistance(), d)
+		fmt.Printf("\n  Distances histogram: %v", db.Histogram())
+		if err := db.Save(PENNANT_FILE); err != nil {
+			fmt.Printf("\n  Not saved: %v", err)
+		}
+	}
+
+	// COLOR WHEELS
+	colorWheels := puzzles.Lookup("ColorWheels").NewEngelGame()
+
+	target := colorWheels.State().(*engel.EngelState)
+	db, _, err = pdb.BuildEngel(colorWheels, target, []int{1, 3, 5, 7, 9, 11}, MAX_STATES)
+	if err != nil {
+		fmt.Printf("\n Color Wheels database not built: %v", err)
+	} else {
+		fmt.Printf("\n COLOR WHEELS: %d abstract states, max distance %d", db.Size(), db.MaxDistance())
+		fmt.Printf("\n  Distances histogram: %v", db.Histogram())
+		if err := db.Save(COLOR_WHEELS_FILE); err != nil {
+			fmt.Printf("\n  Not saved: %v", err)
+		}
+	}
+	fmt.Println("")
+}
@@ -0,0 +1,34 @@
+package main
+
+import "flag"
+import "fmt"
+import "os"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/service"
+
+var (
+	serviceFlag = flag.String("service", "", "serves the JSON solver API at this address (e.g. localhost:8081)")
+	workersFlag = flag.Int("workers", 2, "with -service, number of jobs run at the same time")
+	queueFlag   = flag.Int("queue", 16, "with -service, max number of jobs waiting for a worker")
+)
+
+// Serves the solver service until it fails
+func serveService(addr string) {
+	s := service.NewService(*workersFlag, *queueFlag)
+	if *maxDepthFlag > 0 || *maxStatesFlag > 0 {
+		maxDepth, maxStates := service.MAX_DEPTH, service.MAX_STATES
+		if *maxDepthFlag > 0 {
+			maxDepth = *maxDepthFlag
+		}
+		if *maxStatesFlag > 0 {
+			maxStates = *maxStatesFlag
+		}
+		s.SetLimits(maxDepth, maxStates)
+	}
+
+	fmt.Printf("Solver service at http://%s/jobs\n", addr)
+	if err := s.ListenAndServe(addr); err != nil {
+		fmt.Fprintf(os.Stderr, "Solver service stopped: %v\n", err)
+		os.Exit(1)
+	}
+}
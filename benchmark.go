@@ -0,0 +1,114 @@
+package main
+
+import "flag"
+import "fmt"
+import "os"
+import "strings"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/checks"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/formats"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+var (
+	benchFlag     = flag.Bool("bench", false, "solve the checks catalog and print a results table")
+	csvFlag       = flag.String("csv", "", "with -bench, also write the results to this CSV file")
+	pendingFlag   = flag.Bool("pending", false, "with -bench, include pending puzzles")
+	onlyFlag      = flag.String("only", "", "with -bench, comma separated puzzle names to run")
+	maxDepthFlag  = flag.Int("max-depth", 0, "with -bench, overrides each puzzle max depth; with -service, max depth of the jobs; with -farthest, max moves")
+	maxStatesFlag = flag.Int("max-states", 0, "with -bench, overrides each puzzle max states; with -service, max states of the jobs; with -farthest, max states")
+	listFlag      = flag.Bool("list", false, "list the puzzles catalog")
+	loadFlag      = flag.String("load", "", "adds the puzzles of a collection file to the catalog (see formats.ReadCollection)")
+	boardFlag     = flag.String("board", "auto", "with -bench and -farthest, board representation: auto, dense, sparse or bit")
+)
+
+// Board representation given by the command line flags
+func boardKind() games.BoardKind {
+	kind, ok := games.BoardKindByName(*boardFlag)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown board: %s\n", *boardFlag)
+		os.Exit(1)
+	}
+	return kind
+}
+
+// Reads a collection of puzzles and adds them to the catalog
+func loadCollection(path string) {
+	f, err := os.Open(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot read collection: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	collection, err := formats.ReadCollection(f)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot read collection %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	for _, p := range collection {
+		if puzzles.Lookup(p.Name) != nil {
+			fmt.Fprintf(os.Stderr, "Puzzle %s already in the catalog\n", p.Name)
+			os.Exit(1)
+		}
+		puzzles.Register(p)
+	}
+}
+
+// Prints the puzzles catalog
+func listPuzzles() {
+	kinds := map[int]string{puzzles.SBP_PUZZLE: "SBP", puzzles.ENGEL_PUZZLE: "Engel"}
+
+	fmt.Printf("%-22s %-6s %8s  %-20s %s\n", "PUZZLE", "KIND", "OPTIMAL", "AUTHOR", "SOURCE")
+	for _, p := range puzzles.All() {
+		optimal := "?"
+		if p.Optimal > 0 {
+			optimal = fmt.Sprint(p.Optimal)
+		}
+		if p.Pending {
+			optimal += "*"
+		}
+		fmt.Printf("%-22s %-6s %8s  %-20s %s\n", p.Name, kinds[p.Kind], optimal, p.Author, p.Source)
+	}
+	fmt.Println("\n* pending: the solver does not find the optimal yet")
+}
+
+// Runs the benchmark over the checks catalog, as configured by the command line flags.
+func runBenchmark() {
+	cases := checks.Cases(*pendingFlag)
+
+	if *onlyFlag != "" {
+		cases = nil
+		for _, name := range strings.Split(*onlyFlag, ",") {
+			c := puzzles.Lookup(strings.TrimSpace(name))
+			if c == nil {
+				fmt.Fprintf(os.Stderr, "Unknown puzzle: %s\n", name)
+				os.Exit(1)
+			}
+			if c.Kind != puzzles.SBP_PUZZLE {
+				fmt.Fprintf(os.Stderr, "Not a sliding blocks puzzle: %s\n", name)
+				os.Exit(1)
+			}
+			cases = append(cases, c)
+		}
+	}
+
+	limits := checks.BenchmarkLimits{MaxDepth: *maxDepthFlag, MaxStates: *maxStatesFlag, Board: boardKind()}
+	results := checks.RunBenchmark(cases, limits)
+
+	checks.PrintTable(os.Stdout, results)
+
+	if *csvFlag != "" {
+		f, err := os.Create(*csvFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot write CSV: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		if err := checks.WriteCSV(f, results); err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot write CSV: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package checks
+
+import "encoding/csv"
+import "fmt"
+import "io"
+import "strconv"
+import "time"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/finder"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/render"
+
+// Overrides the case limits when not 0
+type BenchmarkLimits struct {
+	MaxDepth  int
+	MaxStates int
+
+	// Board representation of the states (see games.SBGame.SetBoardKind)
+	Board games.BoardKind
+}
+
+// Result of solving one case
+type BenchmarkResult struct {
+	Name      string
+	Found     bool
+	Length    int
+	Optimal   int
+	States    int
+	Duration  time.Duration
+	EndStatus string
+
+	// Name of the metric of Length and Optimal: the puzzle one
+	Metric string
+
+	// Steps of the solution found
+	Solution []defs.Command
+}
+
+// Solution found, and optimal if the optimum is known
+func (r *BenchmarkResult) Pass() bool {
+	return r.Found && (r.Optimal == 0 || r.Length == r.Optimal)
+}
+
+// Sliding blocks puzzles of the catalog. Pending puzzles are included only if asked.
+func Cases(withPending bool) []*puzzles.Puzzle {
+	return puzzles.ByKind(puzzles.SBP_PUZZLE, withPending)
+}
+
+// Builds the game and solves it in the puzzle metric, with the puzzle limits overridden by 'limits'.
+// Move metric puzzles are solved by SbpBfsFinder, the others by MoveExtremalFinder.
+func RunCase(c *puzzles.Puzzle, limits BenchmarkLimits, silent bool) BenchmarkResult {
+
+	// Define the game
+	myPuzzle := c.NewSBGameOn(limits.Board)
+
+	maxDepth := c.MaxDepth
+	if limits.MaxDepth > 0 {
+		maxDepth = limits.MaxDepth
+	}
+	maxStates := c.MaxStates
+	if limits.MaxStates > 0 {
+		maxStates = limits.MaxStates
+	}
+
+	metric := c.Metric
+	if metric == nil {
+		metric = defs.MoveMetric()
+	}
+	if metric != defs.MoveMetric() {
+		return runMetricCase(c, myPuzzle, metric, maxDepth, maxStates, silent)
+	}
+
+	// FINDER ---------------------
+	var sbpFinder finder.SbpBfsFinder
+
+	sbpFinder.SilentMode(silent)
+	sbpFinder.SetDebug(false)
+	sbpFinder.SetLimits(maxDepth, maxStates)
+	sbpFinder.SetHardOptimal(true)
+
+	sbpFinder.DetectGoal(c.Target())
+	sbpFinder.SolvePuzzle(myPuzzle)
+
+	found, solutionLen, duration := sbpFinder.GetResult()
+
+	return BenchmarkResult{
+		Name:      c.Name,
+		Found:     found,
+		Length:    solutionLen,
+		Optimal:   c.Optimal,
+		States:    sbpFinder.StatesCount(),
+		Duration:  duration,
+		EndStatus: sbpFinder.EndStatus(),
+		Metric:    metric.Name(),
+		Solution:  sbpFinder.Solution(),
+	}
+}
+
+// Solves the game in another metric than the move one
+func runMetricCase(c *puzzles.Puzzle, g defs.Playable, metric defs.Metric, maxDepth int, maxStates int, silent bool) BenchmarkResult {
+	var metricFinder finder.MoveExtremalFinder
+
+	metricFinder.SilentMode(silent)
+	metricFinder.SetLimits(maxDepth, maxStates)
+	metricFinder.SetMetric(metric)
+
+	metricFinder.DetectGoal(c.Target())
+	metricFinder.SolvePuzzle(g)
+
+	found, solutionLen, duration := metricFinder.GetResult()
+
+	return BenchmarkResult{
+		Name:      c.Name,
+		Found:     found,
+		Length:    solutionLen,
+		Optimal:   c.Optimal,
+		States:    metricFinder.StatesCount(),
+		Duration:  duration,
+		EndStatus: metricFinder.EndStatus(),
+		Metric:    metric.Name(),
+		Solution:  metricFinder.Solution(),
+	}
+}
+
+// Solves all the cases, silently.
+func RunBenchmark(cases []*puzzles.Puzzle, limits BenchmarkLimits) []BenchmarkResult {
+	var results []BenchmarkResult
+	for _, c := range cases {
+		results = append(results, RunCase(c, limits, true))
+	}
+	return results
+}
+
+// Writes the results as a text table
+func PrintTable(w io.Writer, results []BenchmarkResult) {
+	fmt.Fprintf(w, "%-22s %8s %8s %-8s %10s %12s  %s\n", "PUZZLE", "LENGTH", "OPTIMAL", "METRIC", "STATES", "TIME", "RESULT")
+
+	passed := 0
+	for _, r := range results {
+		length := "-"
+		if r.Found {
+			length = strconv.Itoa(r.Length)
+		}
+		optimal := "?"
+		if r.Optimal > 0 {
+			optimal = strconv.Itoa(r.Optimal)
+		}
+		status := "FAIL"
+		if r.Pass() {
+			status = "ok"
+			passed++
+		}
+		fmt.Fprintf(w, "%-22s %8s %8s %-8s %10d %12s  %s\n", r.Name, length, optimal, r.Metric, r.States, r.Duration.Round(time.Millisecond), status)
+	}
+	fmt.Fprintf(w, "\n%d/%d passed\n", passed, len(results))
+}
+
+// Writes the results as CSV, with a header row
+func WriteCSV(w io.Writer, results []BenchmarkResult) (err error) {
+	out := csv.NewWriter(w)
+
+	out.Write([]string{"puzzle", "found", "length", "optimal", "states", "time_ms", "pass", "end_status", "metric"})
+	for _, r := range results {
+		out.Write([]string{
+			r.Name,
+			strconv.FormatBool(r.Found),
+			strconv.Itoa(r.Length),
+			strconv.Itoa(r.Optimal),
+			strconv.Itoa(r.States),
+			strconv.FormatInt(r.Duration.Nanoseconds()/int64(time.Millisecond), 10),
+			strconv.FormatBool(r.Pass()),
+			r.EndStatus,
+			r.Metric,
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// Runs a puzzle as the check functions always did: with console output, and reporting non optimal solutions.
+func runCheck(name string, label string) {
+	c := puzzles.Lookup(name)
+	if c == nil {
+		panic("[checks::runCheck] unknown puzzle " + name)
+	}
+	r := RunCase(c, BenchmarkLimits{}, false)
+
+	if !r.Found {
+		fmt.Printf("%s not solved!\n", label)
+		printBoards(c)
+	} else if r.Optimal == 0 {
+		fmt.Printf("%s solution: found len = %d\n\n", label, r.Length)
+	} else if r.Length != r.Optimal {
+		fmt.Printf("%s solution not optimal: found len = %d\n\n", label, r.Length)
+		printBoards(c)
+	}
+}
+
+// Draws start and goal boards, to check the puzzle definition
+func printBoards(c *puzzles.Puzzle) {
+	opts := render.Options{Color: true, Lattice: c.Lattice}
+
+	fmt.Printf("Start:\n%s\nGoal:\n%s\n", render.Board(&c.Start, opts), render.Board(&c.Goal, opts))
+	fmt.Printf("(Run 'go run . -show %s' to see the solution step by step)\n\n", c.Name)
+}
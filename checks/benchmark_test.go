@@ -0,0 +1,70 @@
+package checks
+
+import "bytes"
+import "strings"
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Solves the fastest puzzles of the catalog, all in the same process.
+func TestBenchmarkQuickCases(t *testing.T) {
+	var cases []*puzzles.Puzzle
+	for _, name := range []string{"Pennant", "Quzzle", "ChrisMoon", "ChrisPacmen"} {
+		c := puzzles.Lookup(name)
+		if c == nil {
+			t.Fatalf("Case %s not registered", name)
+		}
+		cases = append(cases, c)
+	}
+
+	results := RunBenchmark(cases, BenchmarkLimits{})
+	for _, r := range results {
+		if !r.Pass() {
+			t.Errorf("%s: found %v, length %d, should be %d", r.Name, r.Found, r.Length, r.Optimal)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := WriteCSV(&out, results); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != len(results)+1 {
+		t.Errorf("CSV should have %d lines, has %d", len(results)+1, lines)
+	}
+}
+
+// Cases are solved, and their lengths reported, in the puzzle metric.
+func TestBenchmarkMetric(t *testing.T) {
+	moves := RunCase(puzzles.Lookup("Pennant"), BenchmarkLimits{}, true)
+	if moves.Metric != "move" || moves.Length != 59 {
+		t.Errorf("Pennant: length %d in %s metric, should be 59 in move metric", moves.Length, moves.Metric)
+	}
+
+	steps := *puzzles.Lookup("Pennant")
+	steps.Metric = defs.StepMetric()
+	steps.Optimal = 0
+	r := RunCase(&steps, BenchmarkLimits{}, true)
+	if !r.Found || r.Metric != "step" || r.Length <= moves.Length {
+		t.Fatalf("Pennant in step metric: found %v, length %d in %s metric", r.Found, r.Length, r.Metric)
+	}
+	if l := defs.PathLength(defs.StepMetric(), r.Solution); l != r.Length {
+		t.Errorf("Solution has %d steps, length is %d", l, r.Length)
+	}
+}
+
+// Solves Quzzle on the matrix board and on the bitboard.
+func BenchmarkBoards(b *testing.B) {
+	c := puzzles.Lookup("Quzzle")
+	for _, name := range []string{"dense", "bit"} {
+		kind, _ := games.BoardKindByName(name)
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if r := RunCase(c, BenchmarkLimits{Board: kind}, true); !r.Pass() {
+					b.Fatalf("Quzzle not solved on %s board", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package checks
+
+// Each check solves a puzzle of the catalog (see puzzles package) with console output.
+
+func CheckAdelaaR() {
+	runCheck("AdelaaR", "AdelaaR")
+}
+
+func CheckAneRouge() {
+	runCheck("AneRouge", "AneRouge")
+}
+
+func CheckChrisEye() {
+	runCheck("ChrisEye", "Chris-Eye")
+}
+
+func CheckChrisHeart() {
+	runCheck("ChrisHeart", "Chris-Heart")
+}
+
+func CheckChrisIce() {
+	runCheck("ChrisIce", "Chris-Ice")
+}
+
+func CheckChrisIris() {
+	runCheck("ChrisIris", "Chris-Iris")
+}
+
+func CheckChrisLightning() {
+	runCheck("ChrisLightning", "Chris-Lightning")
+}
+
+func CheckChrisMoon() {
+	runCheck("ChrisMoon", "Chris-Moon")
+}
+
+func CheckChrisPacmen() {
+	runCheck("ChrisPacmen", "Chris-Pacmen")
+}
+
+func CheckChrisSkull() {
+	runCheck("ChrisSkull", "Chris-Skull")
+}
+
+func CheckChrisStar() {
+	runCheck("ChrisStar", "Chris-Star")
+}
+
+func CheckChrisSun() {
+	runCheck("ChrisSun", "Chris-Sun")
+}
+
+func CheckHIFI() {
+	runCheck("HIFI", "HIFI")
+}
+
+func CheckPennant() {
+	runCheck("Pennant", "Pennant")
+}
+
+func CheckQuzzle() {
+	runCheck("Quzzle", "Quzzle")
+}
+
+func CheckRedditQuest4hj6nb() {
+	runCheck("RedditQuest4hj6nb", "P_4hj6nb")
+}
+
+func CheckSuperCentury() {
+	runCheck("SuperCentury", "CheckSuperCentury")
+}
+
+func CheckSuperCompo() {
+	runCheck("SuperCompo", "SuperCompo")
+}
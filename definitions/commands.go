@@ -47,15 +47,7 @@ func (s *CmdStack) Last() Command {
 
 // Returns number of movements/commands using 'move metric': two consecutive movements on the same piece count as 1 movement.
 func (s *CmdStack) MovMetric() int {
-	movs := 0
-	lastPieceId := 0
-	for _, m := range s.stack_ {
-		if lastPieceId != m.PieceId() {
-			movs++
-			lastPieceId = m.PieceId()
-		}
-	}
-	return movs
+	return PathLength(MoveMetric(), s.stack_)
 }
 
 // Makes a copy of the list of movements
@@ -1,5 +1,7 @@
 package defs
 
+import "sync/atomic"
+
 // Generic game state. Used as node in algorithm searchs
 type GameState interface {
 
@@ -28,3 +30,11 @@ type GameState interface {
 	// Returns whether the state is the root state of all explorations
 	Initial() bool
 }
+
+// Last state uid. Updated atomically: searches may run concurrently.
+var lastUid_ int64 = 0
+
+// A new unique identifier for a state instance (see GameState.Uid)
+func NextUid() int {
+	return int(atomic.AddInt64(&lastUid_, 1))
+}
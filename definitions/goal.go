@@ -0,0 +1,6 @@
+package defs
+
+// Condition the solutions of a puzzle must meet. Finders search for the states reaching it.
+type Goal interface {
+	Reached(s SeqGameState) bool
+}
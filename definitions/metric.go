@@ -0,0 +1,173 @@
+package defs
+
+// Length of paths, added step by step: the cost of a step may depend on the previous one.
+type Metric interface {
+	Name() string
+
+	// Cost of doing mov right after prev (nil for the first step of a path). Never negative.
+	Cost(prev Command, mov Command) int
+
+	// What the cost needs from the previous step: nothing, its piece, or its piece and direction.
+	// Searches use it to tell apart the ways of reaching a state.
+	Memory() int
+}
+
+// Metric memories
+const (
+	NO_MEMORY = iota
+	PIECE_MEMORY
+	DIRECTION_MEMORY
+)
+
+// Commands displacing a piece, like grid moves
+type Translation interface {
+	Translation() (dRow int, dCol int)
+}
+
+// Commands displacing a piece in 3D, like voxel grid moves
+type Translation3 interface {
+	Translation() (dLayer int, dRow int, dCol int)
+}
+
+// Length of the path in the metric
+func PathLength(m Metric, path []Command) int {
+	length := 0
+	var prev Command
+	for _, mov := range path {
+		length += m.Cost(prev, mov)
+		prev = mov
+	}
+	return length
+}
+
+// Each step counts 1
+func StepMetric() Metric {
+	return stepMetric{}
+}
+
+// Each turn of a wheel counts 1, whatever its angle (Engel puzzles)
+func TurnMetric() Metric {
+	return turnMetric{}
+}
+
+// Any number of consecutive steps of the same piece counts 1
+func MoveMetric() Metric {
+	return moveMetric{}
+}
+
+// Any number of consecutive steps of the same piece in the same direction counts 1. Commands must
+// implement Translation.
+func StraightLineMetric() Metric {
+	return straightLineMetric{}
+}
+
+// The base metric cost, multiplied by the weight of the moved piece (1 if not given). Alike pieces
+// should have the same weight.
+func WeightedMetric(base Metric, weights map[int]int) Metric {
+	return &weightedMetric{base, weights}
+}
+
+// Metric by name: "step", "turn", "move" or "straight". Nil if unknown.
+func MetricByName(name string) Metric {
+	for _, m := range []Metric{StepMetric(), TurnMetric(), MoveMetric(), StraightLineMetric()} {
+		if m.Name() == name {
+			return m
+		}
+	}
+	return nil
+}
+
+type stepMetric struct{}
+
+func (stepMetric) Name() string {
+	return "step"
+}
+func (stepMetric) Cost(prev Command, mov Command) int {
+	return 1
+}
+func (stepMetric) Memory() int {
+	return NO_MEMORY
+}
+
+type turnMetric struct{}
+
+func (turnMetric) Name() string {
+	return "turn"
+}
+func (turnMetric) Cost(prev Command, mov Command) int {
+	return 1
+}
+func (turnMetric) Memory() int {
+	return NO_MEMORY
+}
+
+type moveMetric struct{}
+
+func (moveMetric) Name() string {
+	return "move"
+}
+func (moveMetric) Cost(prev Command, mov Command) int {
+	if prev != nil && prev.PieceId() == mov.PieceId() {
+		return 0
+	}
+	return 1
+}
+func (moveMetric) Memory() int {
+	return PIECE_MEMORY
+}
+
+type straightLineMetric struct{}
+
+func (straightLineMetric) Name() string {
+	return "straight"
+}
+func (straightLineMetric) Cost(prev Command, mov Command) int {
+	if prev == nil || prev.PieceId() != mov.PieceId() {
+		return 1
+	}
+
+	if p, ok := prev.(Translation3); ok {
+		if m, ok := mov.(Translation3); ok {
+			pLayer, pRow, pCol := p.Translation()
+			mLayer, mRow, mCol := m.Translation()
+			if pLayer == mLayer && pRow == mRow && pCol == mCol {
+				return 0
+			}
+			return 1
+		}
+	}
+
+	p, ok1 := prev.(Translation)
+	m, ok2 := mov.(Translation)
+	if !ok1 || !ok2 {
+		panic("[defs::StraightLineMetric] commands without translation!")
+	}
+	pRow, pCol := p.Translation()
+	mRow, mCol := m.Translation()
+	if pRow == mRow && pCol == mCol {
+		return 0
+	}
+	return 1
+}
+func (straightLineMetric) Memory() int {
+	return DIRECTION_MEMORY
+}
+
+type weightedMetric struct {
+	base_    Metric
+	weights_ map[int]int
+}
+
+func (m *weightedMetric) Name() string {
+	return "weighted " + m.base_.Name()
+}
+func (m *weightedMetric) Cost(prev Command, mov Command) int {
+	w, ok := m.weights_[mov.PieceId()]
+	if !ok {
+		w = 1
+	}
+	return w * m.base_.Cost(prev, mov)
+}
+func (m *weightedMetric) Memory() int {
+	return m.base_.Memory()
+}
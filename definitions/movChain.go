@@ -0,0 +1,76 @@
+package defs
+
+// Persistent list of movements from the initial state: each chain keeps its last movement and a
+// reference to the chain it extends, so chains share their common prefix instead of copying it.
+// Chains are never modified once built. The nil chain is the empty path.
+type MovChain struct {
+	prev_ *MovChain
+	mov_  Command
+
+	// Number of movements, and number of them counting consecutive movements of a piece as one
+	len_       int
+	collapsed_ int
+}
+
+// Returns a new chain, this one followed by the movement
+func (c *MovChain) Add(mov Command) *MovChain {
+	n := &MovChain{prev_: c, mov_: mov, len_: 1, collapsed_: 1}
+	if c != nil {
+		n.len_ = c.len_ + 1
+		n.collapsed_ = c.collapsed_
+		if c.mov_.PieceId() != mov.PieceId() {
+			n.collapsed_++
+		}
+	}
+	return n
+}
+
+func (c *MovChain) Len() int {
+	if c == nil {
+		return 0
+	}
+	return c.len_
+}
+
+// Two or more consecutive movs on the same piece count as only one movement!
+func (c *MovChain) CollapsedLen() int {
+	if c == nil {
+		return 0
+	}
+	return c.collapsed_
+}
+
+// Last movement, nil if the chain is empty
+func (c *MovChain) Last() Command {
+	if c == nil {
+		return nil
+	}
+	return c.mov_
+}
+
+// The movements, from the first one
+func (c *MovChain) Slice() []Command {
+	movs := make([]Command, c.Len())
+	for x := c; x != nil; x = x.prev_ {
+		movs[x.len_-1] = x.mov_
+	}
+	return movs
+}
+
+// The last consecutive movements of the same piece, from the last one
+func (c *MovChain) LastPieceMovs() []Command {
+	var movs []Command
+	for x := c; x != nil && x.mov_.PieceId() == c.mov_.PieceId(); x = x.prev_ {
+		movs = append(movs, x.mov_)
+	}
+	return movs
+}
+
+// Chain of the movements, from the first one
+func NewMovChain(movs []Command) *MovChain {
+	var c *MovChain
+	for _, m := range movs {
+		c = c.Add(m)
+	}
+	return c
+}
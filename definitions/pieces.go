@@ -4,14 +4,18 @@ package defs
 // It holds the 'alike pieces' piece id to common value. All alike pieces will have the
 // same value, so when comparing states, any switch between alike pieces will generate equivalent
 // states.
-// Static, global or common for all states.
+// Each game owns its map and shares it with all its states, so several puzzles can be solved
+// in the same process.
 type PieceToValue struct {
 	idToValue_ map[int]int
 }
 
-// Value corresponding to a piece id
+// Value corresponding to a piece id. Pieces without an assigned value are their own value.
 func (ptv *PieceToValue) At(id int) int {
-	return ptv.idToValue_[id]
+	if v, ok := ptv.idToValue_[id]; ok {
+		return v
+	}
+	return id
 }
 
 // Set a piece id-value correspondence
@@ -19,15 +23,10 @@ func (ptv *PieceToValue) Set(id int, value int) {
 	ptv.idToValue_[id] = value
 }
 
-// Shared among all states, static map.
-var s_ptv_ *PieceToValue = nil
-
-// Returns the global map
-func GetPieceToValueMap() *PieceToValue {
-	if s_ptv_ == nil {
-		s_ptv_ = &PieceToValue{}
-		s_ptv_.idToValue_ = make(map[int]int)
-		s_ptv_.idToValue_[0] = 0
-	}
-	return s_ptv_
+// Returns a new, empty map. Empty cells (id 0) have value 0.
+func NewPieceToValue() *PieceToValue {
+	ptv := &PieceToValue{}
+	ptv.idToValue_ = make(map[int]int)
+	ptv.idToValue_[0] = 0
+	return ptv
 }
@@ -25,7 +25,7 @@ type SeqGameState interface {
 
 	// These functions have been being added while developing the BFS algorithm
 	// Should refactor.
-	SetMovChain([]Command, *SeqGameState)
+	SetMovChain(*MovChain, *SeqGameState)
 	CollapsedPathLen() int
 	RealPathLen() int
 	CopyMovChainFrom(SeqGameState)
@@ -36,8 +36,11 @@ type SeqGameState interface {
 	PrevMov() Command
 	UpdateFromStart(originState *SeqGameState)
 
-	CopyMovChainAndAdd([]Command, Command, *SeqGameState)
+	CopyMovChainAndAdd(*MovChain, Command, *SeqGameState)
 
+	// Shortest path known to the state, and the path following the previous states
+	MovChain() *MovChain
+	ParentPath() *MovChain
 	PathChain() []Command
 	BuildPathReversed(path *[]Command)
 
@@ -51,7 +54,7 @@ type SeqGameState interface {
 
 	// Adds an equivalent node-path. Finally if this node is part of a solution, we can check all the descendant paths to origin
 	// and select the shortest.
-	AddEquivPath(SeqGameState, []Command, Command)
+	AddEquivPath(SeqGameState, *MovChain, Command)
 	ValidMovement(m Command) bool
 	ApplyEquivalencyContinuity(SeqGameState, Command, SeqGameState) bool
 
@@ -0,0 +1,77 @@
+package main
+
+import "flag"
+import "fmt"
+import "os"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/finder"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/render"
+
+var (
+	farthestFlag = flag.String("farthest", "", "finds the farthest states from the start of this puzzle")
+	metricFlag   = flag.String("metric", "move", "with -farthest, distance metric: step, move or straight")
+	symmetryFlag = flag.Bool("symmetry", false, "with -farthest, counts mirror states once if the start is symmetric")
+)
+
+// Default limits of the farthest states search
+const (
+	FARTHEST_MAX_MOVES  = 1000
+	FARTHEST_MAX_STATES = 5000000
+)
+
+// Prints the farthest states from the start of a puzzle of the catalog, with the distances histogram
+func showFarthest(name string) {
+	p := puzzles.Lookup(name)
+	if p == nil || p.Kind != puzzles.SBP_PUZZLE {
+		fmt.Fprintf(os.Stderr, "Unknown sliding blocks puzzle: %s\n", name)
+		os.Exit(1)
+	}
+	metric := defs.MetricByName(*metricFlag)
+	if metric == nil {
+		fmt.Fprintf(os.Stderr, "Unknown metric: %s\n", *metricFlag)
+		os.Exit(1)
+	}
+
+	maxMoves, maxStates := FARTHEST_MAX_MOVES, FARTHEST_MAX_STATES
+	if *maxDepthFlag > 0 {
+		maxMoves = *maxDepthFlag
+	}
+	if *maxStatesFlag > 0 {
+		maxStates = *maxStatesFlag
+	}
+
+	var f finder.MoveExtremalFinder
+	f.SilentMode(true)
+	f.SetLimits(maxMoves, maxStates)
+	f.SetSymmetry(*symmetryFlag)
+	f.SetMetric(metric)
+	f.FindExtremals(p.NewSBGameOn(boardKind()))
+
+	fmt.Printf("%s: %d states (%s)\n", p.Name, f.StatesCount(), f.EndStatus())
+	if syms := f.Symmetries(); len(syms) > 0 {
+		fmt.Printf("Up to symmetry: %v\n", syms)
+	}
+	fmt.Printf("\nStates by distance:\n")
+	histogram := f.Histogram()
+	for d := 0; d <= f.Distance(); d++ {
+		fmt.Printf("%4d: %d\n", d, histogram[d])
+	}
+
+	opts := render.Options{ASCII: *asciiFlag, Color: *colorFlag, Lattice: p.Lattice}
+	extremals := f.Extremals()
+	fmt.Printf("\nFarthest states: %d, at distance %d (%s metric)\n", len(extremals), f.Distance(), metric.Name())
+	for _, s := range extremals {
+		fmt.Print(render.Board(s.(*games.SBPState).Grid(), opts))
+	}
+
+	fmt.Print("\nPath to the first one:")
+	for _, m := range f.ExamplePath() {
+		dRow, dCol := m.(*grids.GridMov2).Translation()
+		fmt.Printf(" [%d, %d, %d]", m.PieceId(), dRow, dCol)
+	}
+	fmt.Println()
+}
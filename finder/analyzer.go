@@ -6,41 +6,20 @@ import "github.com/fatih/color"
 
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/utils"
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/search"
 
-// Sliding blocks puzzle finder
+// Puzzle explorer: reaches all the states of a puzzle with the search engine, and prints their statistics
 type Analyzer struct {
 
 	// Params
-	limits_       FinderLimits
-	silent_       bool
-	hardOptimals_ bool
-
-	// Game settings
-	game_      defs.Explorable
-	initState_ defs.GameState
-
-	// If we are searching for a concrete state
-	extremals_     []defs.GameState
-	extremalDist_  int
-	findExtremals_ bool
-
-	// Stats
-	countStates_          utils.ScalarStatistic
-	nodesDegree_          utils.ScalarStatistic
-	frontierSize_         utils.RangeStatistic
-	maxDepth_             utils.RangeStatistic
-	depthDistr_           utils.RangeHistogram
-	revisitedStates_      utils.ScalarStatistic
-	revisitNotIgnorables_ utils.RangeHistogram
+	limits_  FinderLimits
+	silent_  bool
+	control_ *SearchControl
 
-	// Algorithm state
-	visitedStates_  map[int][]defs.GameState
-	frontier_       utils.Queue
-	farthestStates_ utils.Queue
-	maxFarthest_    int
-	nextFrontier_   []defs.GameState
-	endStatus_      string
-	duration_       time.Duration
+	// Stats of the last exploration
+	engine_               engineStats
+	revisitNotIgnorables_ utils.RangeHistogram
+	farthestStates_       []func()
 
 	initialized_ bool
 	debug_       bool
@@ -52,10 +31,24 @@ type Analyzer struct {
 	outDbg3_    *color.Color //different
 }
 
+// What the analyzer reports from the search engine, whatever its states
+type engineStats interface {
+	StatesCount() int
+	DepthDistribution() map[int]int
+	EndStatus() string
+	Duration() time.Duration
+	Resume(out *color.Color)
+}
+
+// Farthest states printed by Resume
+const MAX_FARTHEST_PRINTED = 3
+
 func (f *Analyzer) SetDebug(b bool) {
 	f.debug_ = b
 }
 
+// States deeper than maxDepth are not expanded, and the exploration stops when the start and the states
+// taken from the frontier reach maxStates (0 is no limit)
 func (f *Analyzer) SetLimits(maxDepth int, maxStates int) {
 	f.limits_.SetLimits(maxDepth, maxStates)
 }
@@ -63,6 +56,43 @@ func (f *Analyzer) SilentMode(b bool) {
 	f.silent_ = b
 }
 
+// Publishes the progress of the next explorations to c, and stops them when c is cancelled
+func (f *Analyzer) SetControl(c *SearchControl) {
+	f.control_ = c
+}
+
+// Number of different states reached by the last exploration
+func (f *Analyzer) StatesCount() int {
+	if f.engine_ == nil {
+		return 0
+	}
+	return f.engine_.StatesCount()
+}
+
+// Number of states reached at each depth by the last exploration
+func (f *Analyzer) DepthDistribution() map[int]int {
+	if f.engine_ == nil {
+		return nil
+	}
+	return f.engine_.DepthDistribution()
+}
+
+// Reason why the last exploration stopped
+func (f *Analyzer) EndStatus() string {
+	if f.engine_ == nil {
+		return ""
+	}
+	return f.engine_.EndStatus()
+}
+
+// Duration of the last exploration
+func (f *Analyzer) Duration() time.Duration {
+	if f.engine_ == nil {
+		return 0
+	}
+	return f.engine_.Duration()
+}
+
 func (f *Analyzer) init() {
 	f.fmtHeaders_ = color.New(color.FgCyan, color.Bold)
 
@@ -70,17 +100,7 @@ func (f *Analyzer) init() {
 	f.outDbg2_ = color.New(color.FgWhite)
 	f.outDbg3_ = color.New(color.FgYellow)
 
-	f.countStates_.Set("States")
-	f.nodesDegree_.Set("Node degree")
-	f.frontierSize_.Set("Frontier size")
-	f.maxDepth_.Set("Max depth")
-	f.depthDistr_.Set("Depth states distribution")
-	f.revisitedStates_.Set("Revisited states")
-	f.revisitNotIgnorables_.Set("Revisited not ignorables")
-
 	f.initialized_ = true
-	f.nextDepth_ = 0
-	f.maxFarthest_ = 12
 }
 
 // Prints statistics and results
@@ -90,24 +110,15 @@ func (f *Analyzer) Resume() {
 	}
 
 	f.fmtHeaders_.Println("\n[STATS]")
-	f.countStates_.Resume(f.outDbg2_)
-	f.revisitedStates_.Resume(f.outDbg2_)
+	if f.engine_ != nil {
+		f.engine_.Resume(f.outDbg2_)
+	}
 	f.revisitNotIgnorables_.ResumeHistogramUnsort(f.outDbg2_)
-	f.nodesDegree_.ResumeAv(f.outDbg2_)
-	f.frontierSize_.ResumeRange(f.outDbg2_)
-	f.maxDepth_.ResumeRange(f.outDbg2_)
-	f.depthDistr_.ResumeHistogram(f.outDbg2_)
 
-	//Farthest states: print 3
 	f.fmtHeaders_.Println("\n[FARTHEST STATES]")
-	for i := 0; i < 3 && i < f.farthestStates_.Size(); i++ {
-		x := f.farthestStates_.PopFront()
-		if x != nil {
-			s := x.(defs.GameState)
-
-			f.outDbg2_.Printf("\n\n State [%d]:\n", i+1)
-			s.Print()
-		}
+	for i, printState := range f.farthestStates_ {
+		f.outDbg2_.Printf("\n\n State [%d]:\n", i+1)
+		printState()
 	}
 
 	fmt.Println("\n\n")
@@ -115,189 +126,122 @@ func (f *Analyzer) Resume() {
 
 // Explores all possible reachable states
 func (f *Analyzer) Explore(g defs.Explorable) {
+	explore(f, search.Explorable(g), defs.GameState.Print, func(old *search.Node[defs.GameState, defs.Command], child *search.Node[defs.GameState, defs.Command]) bool {
+		st := old.State()
+		mov := child.Mov()
+		st.AddPrevMov(mov)
+
+		// If we happen to revisit initial (root) state, then we should
+		// see if we can ignore sibling states, because they have been all visited.
+		if st.Initial() {
+			return true //Never happens!
+		}
+
+		// If we close a loop going back to 2 or more levels, then we can ignore all siblings
+		// because all of them will have been visited
+		if old.Depth()+2 <= child.Depth() {
+			return true //This never happens!
+		}
+
+		// We can ignore all siblings if the command for st is in the same wheel as the command in s,
+		// because all siblings will have already been generated:
+		return st.PrevMov() != nil && st.PrevMov().PieceId() == mov.PieceId()
+	})
+}
+
+// Explores all the states reachable from the current one of a sequential game (sliding blocks puzzles)
+func (f *Analyzer) ExplorePlayable(g defs.Playable) {
+	explore(f, search.Playable(g), defs.SeqGameState.TinyPrint, nil)
+}
+
+// Explores the game with the search engine. States are printed with printState, and revisit tells if the
+// siblings of a revisiting state can be ignored (see search.Engine.OnRevisit).
+func explore[S search.State[S]](f *Analyzer, g search.Game[S, defs.Command], printState func(S), revisit func(*search.Node[S, defs.Command], *search.Node[S, defs.Command]) bool) {
 	if !f.initialized_ {
 		f.init()
 	}
 	if !f.silent_ {
 		fmt.Println("Puzzle Explorer v.1.0")
 	}
+	f.nextDepth_ = 0
+	f.revisitNotIgnorables_.Set("Revisited not ignorables")
 
-	f.game_ = g
-	f.visitedStates_ = make(map[int][]defs.GameState)
-	f.initState_ = f.game_.State()
-
-	h := f.initState_.ToHash()
-	f.visitedStates_[h] = append(f.visitedStates_[h], f.initState_)
-	f.addToFrontier(f.initState_)
-	f.countStates_.Incr()
-
-	tStart := time.Now()
-	if !f.silent_ {
-		f.fmtHeaders_.Println("\n[WORKING]...")
-	}
-
-	f.exploreTree()
-
-	tEnd := time.Now()
-	f.duration_ = tEnd.Sub(tStart)
-	if !f.silent_ {
-		f.fmtHeaders_.Println("\n[DONE] ", f.duration_)
-		f.fmtHeaders_.Println("\n - End condition: ", f.endStatus_)
+	// The analyzer expands the states at maxDepth too
+	var e search.Engine[S, defs.Command]
+	maxDepth := f.limits_.maxDepth_
+	if maxDepth > 0 {
+		maxDepth++
 	}
-}
-
-func (f *Analyzer) exploreTree() {
-
-	statesCount := 1
-
-	curState := f.popFrontier()
-	for curState != nil {
-
-		statesCount++
-		if f.limits_.maxStates_ > 0 && statesCount >= f.limits_.maxStates_ {
-			f.endStatus_ = "Max states reached."
-			break
-		} else if curState.Depth() > f.limits_.maxDepth_ {
-			f.endStatus_ = "Max depth reached."
-			break
-		}
-
-		if !f.silent_ {
-			if f.nextDepth_ < curState.Depth() {
-				fmt.Printf("\n\n DEPTH %d", f.nextDepth_)
-				fmt.Printf(" -------------------------------------")
-				fmt.Printf("\n States explored: %d", statesCount-1)
-				fmt.Printf("\n\n")
-				f.nextDepth_ = curState.Depth()
-			}
-
-			update := f.limits_.maxStates_ / 100
-			if f.limits_.maxStates_ > 0 && statesCount%update == 0 {
-				pct := (100 * statesCount / f.limits_.maxStates_)
-				fmt.Printf("\n  - Explored states pct: %d%%, frontier: %d", pct, f.frontier_.Size())
-			}
-		}
-
-		f.game_.SetState(curState)
-		validMovs := f.game_.ValidMovements()
-
-		f.nodesDegree_.Add(len(validMovs))
-
+	e.SetLimits(maxDepth, f.limits_.maxStates_)
+	e.OnExpand(func(n *search.Node[S, defs.Command]) string {
 		if f.debug_ {
-			f.outDbg2_.Printf("	 Valid movs:%v", len(validMovs))
+			f.outDbg2_.Printf("\n\n### Explore state at depth %d", n.Depth())
+			printState(n.State())
 		}
-
-		for _, mov := range validMovs {
-
-			f.game_.Move(mov)
-			newState := f.game_.State()
-
-			newState.SetPrevMov(mov)
-
-			ignoreLoop := f.processState(newState)
-
-			if ignoreLoop {
-				break
-			}
-			f.game_.UndoMove(mov)
+		if !f.expanding(n.Depth(), e.Counted(), e.FrontierSize()) {
+			return "Search cancelled."
 		}
-
-		// Let's visite next pending state
-		curState = f.popFrontier()
-	}
-	if curState == nil {
-		f.endStatus_ = "All states explored, no more states in queue"
-	}
-}
-
-func (f *Analyzer) processState(s defs.GameState) bool {
-
-	h := s.ToHash()
+		return ""
+	})
+	e.OnRevisit(func(old *search.Node[S, defs.Command], child *search.Node[S, defs.Command]) bool {
+		if revisit != nil && revisit(old, child) {
+			return true
+		}
+		f.revisitNotIgnorables_.Add(child.Depth(), 1)
+		return false
+	})
 	if f.debug_ {
-		f.outDbg1_.Printf("\n	 - Process state. Hash: %d", h)
+		e.OnState(func(n *search.Node[S, defs.Command]) {
+			f.outDbg2_.Printf("\n	  Add to frontier: state at depth %d", n.Depth())
+		})
 	}
 
-	if f.visitedStates_[h] == nil {
-
-		// Easy, it is a new state
-		f.countStates_.Incr()
-		f.visitedStates_[h] = append(f.visitedStates_[h], s)
-
-		// Let's explore its childs later
-		f.addToFrontier(s)
-	} else {
-
-		// Compare with potential equivalent states
-		for _, st := range f.visitedStates_[h] {
-			if st.Equal(s) {
-				f.revisitedStates_.Incr()
-
-				st.AddPrevMov(s.PrevMov())
-
-				// If we happen to revisit initial (root) state, then we should
-				// see if we can ignore sibling states, because they have been all visited.
-				if st.Initial() {
-					return true //Never happens!
-				}
-
-				// If we close a loop going back to 2 or more levels, then we can ignore all siblings
-				// because all of them will have been visited
-				if st.Depth()+2 <= s.Depth() {
-					return true //This never happens!
-				}
-
-				// We can ignore all siblings if the command for st is in the same wheel as the command in s,
-				// because all siblings will have already been generated:
-				if st.PrevMov() != nil && st.PrevMov().PieceId() == s.PrevMov().PieceId() {
-					return true
-				}
+	if !f.silent_ {
+		f.fmtHeaders_.Println("\n[WORKING]...")
+	}
 
-				//fmt.Printf("\n - Hash hit: st.Depth = %d, s.Depth = %d", st.Depth(), s.Depth())
-				f.revisitNotIgnorables_.Add(s.Depth(), 1)
+	e.Run(g, search.EXTREMALS)
+	f.engine_ = &e
 
-				return false
-			}
+	f.farthestStates_ = nil
+	for i, n := range e.Extremals() {
+		if i == MAX_FARTHEST_PRINTED {
+			break
 		}
-
-		// Ok, new state with hash collision.
-		f.countStates_.Incr()
-		f.visitedStates_[h] = append(f.visitedStates_[h], s)
-
-		// Let's explore its childs later
-		f.addToFrontier(s)
+		s := n.State()
+		f.farthestStates_ = append(f.farthestStates_, func() { printState(s) })
 	}
-	return false
-}
 
-// Push back to the priority queue that state.
-func (f *Analyzer) addToFrontier(s defs.GameState) {
-	if f.debug_ {
-		f.outDbg2_.Printf("\n	  Add to frontier: state [%d]", s.Uid())
+	if !f.silent_ {
+		f.fmtHeaders_.Println("\n[DONE] ", e.Duration())
+		f.fmtHeaders_.Println("\n - End condition: ", e.EndStatus())
 	}
-	f.frontier_.PushBack(s)
-	f.frontierSize_.Add(f.frontier_.Size())
-	f.maxDepth_.Add(s.Depth())
-
-	f.depthDistr_.Add(s.Depth(), 1)
+}
 
-	// Farthest states are always the last added
-	f.farthestStates_.PushBack(s)
-	if f.farthestStates_.Size() > f.maxFarthest_ {
-		f.farthestStates_.PopFront()
+// Publishes the progress before expanding a state, and prints it if not silent. Returns false if the
+// exploration has been cancelled.
+func (f *Analyzer) expanding(depth int, statesCount int, frontierSize int) bool {
+	if f.control_ != nil {
+		if f.control_.Cancelled() {
+			return false
+		}
+		f.control_.update(statesCount, depth)
 	}
-}
 
-// Pop from start of queue (highest priority)
-func (f *Analyzer) popFrontier() defs.GameState {
+	if !f.silent_ {
+		if f.nextDepth_ < depth {
+			fmt.Printf("\n\n DEPTH %d", f.nextDepth_)
+			fmt.Printf(" -------------------------------------")
+			fmt.Printf("\n States explored: %d", statesCount-1)
+			fmt.Printf("\n\n")
+			f.nextDepth_ = depth
+		}
 
-	x := f.frontier_.PopFront()
-	if x != nil {
-		s := x.(defs.GameState)
-		if f.debug_ {
-			f.outDbg2_.Printf("\n\n### Explore state [%d]", s.Uid())
-			s.Print()
+		update := f.limits_.maxStates_ / 100
+		if update > 0 && statesCount%update == 0 {
+			pct := (100 * statesCount / f.limits_.maxStates_)
+			fmt.Printf("\n  - Explored states pct: %d%%, frontier: %d", pct, frontierSize)
 		}
-		return s
 	}
-	return nil
+	return true
 }
@@ -0,0 +1,23 @@
+package finder
+
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// States at the max depth are expanded too: their children are counted, and the search stops after them
+func TestAnalyzerDepth(t *testing.T) {
+	g := puzzles.Lookup("SunMoon").NewEngelGame()
+
+	var a Analyzer
+	a.SilentMode(true)
+	a.SetLimits(1, 0)
+	a.Explore(g)
+
+	d := a.DepthDistribution()
+	if d[1] == 0 || d[2] == 0 || d[3] != 0 {
+		t.Errorf("Depth distribution %v, should reach depth 2", d)
+	}
+	if a.EndStatus() != "Max depth reached." {
+		t.Errorf("End status %q, should reach the depth limit", a.EndStatus())
+	}
+}
@@ -0,0 +1,226 @@
+package finder
+
+import "fmt"
+import "time"
+import "github.com/fatih/color"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/utils"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Meet-in-the-middle finder: breadth first searches from the start and from the target state, expanding
+// the smaller frontier one full level at a time, until they meet.
+//
+// It requires reversible games: the inverse of every movement must be a valid movement too (wheel puzzles).
+// Then, moving backwards from the target uses the same movement generator as moving forward.
+type BidirectionalFinder struct {
+
+	// Params
+	limits_ FinderLimits
+	silent_ bool
+
+	// Stats
+	countStates_  utils.ScalarStatistic
+	frontierSize_ utils.RangeStatistic
+
+	endStatus_ string
+	duration_  time.Duration
+
+	initialized_ bool
+	debug_       bool
+
+	fmtHeaders_ *color.Color
+	outDbg2_    *color.Color
+}
+
+// Node of one of the search trees
+type bidiNode struct {
+	state_  defs.GameState
+	parent_ *bidiNode
+	mov_    defs.Command
+	depth_  int
+}
+
+// One of the two searches
+type bidiSide struct {
+	visited_  map[int][]*bidiNode
+	frontier_ []*bidiNode
+	depth_    int
+}
+
+func (f *BidirectionalFinder) SetDebug(b bool) {
+	f.debug_ = b
+}
+
+// maxDepth limits the solution length; maxStates the states stored by both searches. 0 is no limit.
+func (f *BidirectionalFinder) SetLimits(maxDepth int, maxStates int) {
+	f.limits_.SetLimits(maxDepth, maxStates)
+}
+func (f *BidirectionalFinder) SilentMode(b bool) {
+	f.silent_ = b
+}
+
+func (f *BidirectionalFinder) init() {
+	f.fmtHeaders_ = color.New(color.FgCyan, color.Bold)
+	f.outDbg2_ = color.New(color.FgWhite)
+
+	f.countStates_ = utils.ScalarStatistic{}
+	f.frontierSize_ = utils.RangeStatistic{}
+	f.countStates_.Set("States")
+	f.frontierSize_.Set("Frontier size")
+
+	f.initialized_ = true
+}
+
+// Prints statistics
+func (f *BidirectionalFinder) Resume() {
+	if !f.initialized_ {
+		f.init()
+	}
+
+	f.fmtHeaders_.Println("\n[STATS]")
+	f.countStates_.Resume(f.outDbg2_)
+	f.frontierSize_.ResumeRange(f.outDbg2_)
+	fmt.Println("")
+}
+
+// Reason why last search stopped
+func (f *BidirectionalFinder) EndStatus() string {
+	return f.endStatus_
+}
+
+// Searches for a shortest sequence of movements taking the current game state to the target. States are
+// compared with their Equal method, so alike pieces are respected. Returns false if the limits are reached
+// or the target is not reachable.
+func (f *BidirectionalFinder) Solve(g defs.Explorable, target defs.GameState) (path []defs.Command, found bool) {
+	// Each search starts with fresh statistics
+	f.init()
+	if !f.silent_ {
+		fmt.Println("Bidirectional Finder v.1.0")
+	}
+
+	tStart := time.Now()
+	defer func() {
+		f.duration_ = time.Now().Sub(tStart)
+		if !f.silent_ {
+			f.fmtHeaders_.Println("\n[DONE] ", f.duration_)
+			f.fmtHeaders_.Println("\n - End condition: ", f.endStatus_)
+		}
+	}()
+
+	forward := newBidiSide(g.State())
+	backward := newBidiSide(target)
+	f.countStates_.Add(2)
+
+	if forward.frontier_[0].state_.Equal(target) {
+		f.endStatus_ = "Solution found."
+		return nil, true
+	}
+
+	for {
+		if len(forward.frontier_) == 0 || len(backward.frontier_) == 0 {
+			f.endStatus_ = "All states explored, target not reachable."
+			return nil, false
+		}
+		if f.limits_.maxDepth_ > 0 && forward.depth_+backward.depth_ >= f.limits_.maxDepth_ {
+			f.endStatus_ = "Max depth reached."
+			return nil, false
+		}
+		if f.limits_.maxStates_ > 0 && f.countStates_.Total() >= f.limits_.maxStates_ {
+			f.endStatus_ = "Max states reached."
+			return nil, false
+		}
+
+		// Expand the smaller frontier
+		side, other := forward, backward
+		if len(backward.frontier_) < len(forward.frontier_) {
+			side, other = backward, forward
+		}
+
+		meetA, meetB := f.expandLevel(g, side, other)
+		if meetA != nil {
+			f.endStatus_ = "Solution found."
+			if side == forward {
+				return joinPaths(meetA, meetB), true
+			}
+			return joinPaths(meetB, meetA), true
+		}
+
+		if !f.silent_ {
+			fmt.Printf("\n Depths %d + %d, states: %d", forward.depth_, backward.depth_, f.countStates_.Total())
+		}
+	}
+}
+
+func newBidiSide(s defs.GameState) *bidiSide {
+	root := &bidiNode{state_: s}
+	side := &bidiSide{
+		visited_:  make(map[int][]*bidiNode),
+		frontier_: []*bidiNode{root},
+	}
+	side.add(root)
+	return side
+}
+
+// Returns the stored node equal to the state, or nil
+func (side *bidiSide) find(s defs.GameState) *bidiNode {
+	for _, n := range side.visited_[s.ToHash()] {
+		if n.state_.Equal(s) {
+			return n
+		}
+	}
+	return nil
+}
+
+func (side *bidiSide) add(n *bidiNode) {
+	h := n.state_.ToHash()
+	side.visited_[h] = append(side.visited_[h], n)
+}
+
+// Expands a full level of 'side'. If new states are found in the other search, returns the pair of
+// meeting nodes with the shortest total length.
+func (f *BidirectionalFinder) expandLevel(g defs.Explorable, side *bidiSide, other *bidiSide) (meetSide *bidiNode, meetOther *bidiNode) {
+	var next []*bidiNode
+
+	for _, n := range side.frontier_ {
+		g.SetState(n.state_)
+
+		for _, mov := range g.ValidMovements() {
+			g.Move(mov)
+			s := g.State()
+			s.SetPrevMov(mov)
+			g.UndoMove(mov)
+
+			if side.find(s) != nil {
+				continue
+			}
+
+			child := &bidiNode{s, n, mov, n.depth_ + 1}
+			side.add(child)
+			next = append(next, child)
+			f.countStates_.Incr()
+
+			if match := other.find(s); match != nil {
+				if meetSide == nil || match.depth_ < meetOther.depth_ {
+					meetSide, meetOther = child, match
+				}
+			}
+		}
+	}
+
+	side.frontier_ = next
+	side.depth_++
+	f.frontierSize_.Add(len(next))
+	return
+}
+
+// Joins the forward path to 'a' with the reversed backward path from 'b' to the target.
+func joinPaths(a *bidiNode, b *bidiNode) []defs.Command {
+	var path []defs.Command
+	for n := a; n.parent_ != nil; n = n.parent_ {
+		path = append([]defs.Command{n.mov_}, path...)
+	}
+	for n := b; n.parent_ != nil; n = n.parent_ {
+		path = append(path, n.mov_.Inverted().(defs.Command))
+	}
+	return path
+}
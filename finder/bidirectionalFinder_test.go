@@ -0,0 +1,97 @@
+package finder
+
+import "fmt"
+import "math/rand"
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Number of turns from the game state to the target, with a plain breadth first search
+func engelDistance(g *engel.EngelGame, target defs.GameState) int {
+
+	// States by their piece values
+	visited := make(map[string]bool)
+	seen := func(s defs.GameState) bool {
+		key := fmt.Sprint(g.Coloring(s.(*engel.EngelState)))
+		if visited[key] {
+			return true
+		}
+		visited[key] = true
+		return false
+	}
+
+	level := []defs.GameState{g.State()}
+	seen(level[0])
+	for depth := 0; len(level) > 0; depth++ {
+		var next []defs.GameState
+		for _, s := range level {
+			if s.Equal(target) {
+				return depth
+			}
+			for wheel := 0; wheel < 2; wheel++ {
+				for r := 1; r < 6; r++ {
+					g.SetState(s)
+					g.Move(engel.NewEngelCommand(wheel, r))
+					if n := g.State(); !seen(n) {
+						next = append(next, n)
+					}
+				}
+			}
+		}
+		level = next
+	}
+	return -1
+}
+
+// Solutions are as short as the ones of a plain search, and reach the target
+func TestSolveEngel(t *testing.T) {
+	g := puzzles.Lookup("SunMoon").NewEngelGame()
+	initial := g.State()
+	rnd := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 20; j++ {
+			g.Move(engel.NewEngelCommand(j%2, 1+rnd.Intn(5)))
+		}
+		target := g.State().(*engel.EngelState)
+		g.SetState(initial)
+
+		// No limits
+		var f BidirectionalFinder
+		f.SilentMode(true)
+		solution, err := f.SolveEngel(g, target)
+		if err != nil {
+			t.Fatalf("Scramble %d not solved: %v", i, err)
+		}
+
+		// Searches leave the game in any state
+		g.SetState(initial)
+		if d := engelDistance(g, target); len(solution) != d {
+			t.Errorf("Scramble %d: %d turns, a plain search needs %d", i, len(solution), d)
+		}
+		g.SetState(initial)
+		for _, m := range solution {
+			g.Move(m)
+		}
+		if !g.State().Equal(target) {
+			t.Errorf("Scramble %d: the solution does not reach the target", i)
+		}
+		g.SetState(initial)
+
+		f.SetLimits(2, 0)
+		if _, err := f.SolveEngel(g, target); err == nil || f.EndStatus() != "Max depth reached." {
+			t.Errorf("Scramble %d: should reach the depth limit, got %v", i, err)
+		}
+	}
+
+	// A triangle and a rectangle swapped
+	l, r := initial.(*engel.EngelState).WheelPieces()
+	l[2], l[3] = l[3], l[2]
+	var f BidirectionalFinder
+	f.SilentMode(true)
+	if _, err := f.SolveEngel(g, g.NewState(l, r)); err == nil {
+		t.Errorf("An unreachable target should not be solved")
+	}
+}
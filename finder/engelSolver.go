@@ -0,0 +1,36 @@
+package finder
+
+import "errors"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+
+// Searches for an optimal sequence of wheel turns taking the game initial state to the target. Alike
+// groups defined with EngelGame.SetAlike are respected. Unreachable targets are detected with the
+// permutation group of the puzzle, before any search.
+func (f *BidirectionalFinder) SolveEngel(g *engel.EngelGame, target *engel.EngelState) ([]*engel.EngelCommand, error) {
+	solvable, err := g.Solvable(target)
+	if err == nil && !solvable {
+		return nil, errors.New("target state is not reachable")
+	}
+
+	path, found := f.Solve(g, target)
+	if !found {
+		return nil, errors.New(f.endStatus_)
+	}
+
+	// Merge consecutive turns of the same wheel and normalize rotations
+	var solution []*engel.EngelCommand
+	for _, m := range path {
+		mov := m.(*engel.EngelCommand)
+		if n := len(solution); n > 0 && solution[n-1].PieceId() == mov.PieceId() {
+			merged := engel.NewEngelCommand(mov.PieceId(), solution[n-1].Rotation()+mov.Rotation())
+			solution = solution[:n-1]
+			if merged.Rotation() != 0 {
+				solution = append(solution, merged)
+			}
+			continue
+		}
+		solution = append(solution, engel.NewEngelCommand(mov.PieceId(), mov.Rotation()))
+	}
+	return solution, nil
+}
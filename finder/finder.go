@@ -1,5 +1,7 @@
 package finder
 
+import "sync/atomic"
+
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
 
 // Basic finder/solver interface
@@ -25,3 +27,30 @@ func (f *FinderLimits) SetLimits(maxDepth int, maxStates int) {
 	f.maxDepth_ = maxDepth
 	f.maxStates_ = maxStates
 }
+
+// Lets another goroutine follow a running search and stop it. The finder publishes its progress
+// after each explored state, and checks for cancellation before exploring the next one.
+type SearchControl struct {
+	cancelled_ int32
+	states_    int64
+	depth_     int64
+}
+
+// Asks the search to stop as soon as possible
+func (c *SearchControl) Cancel() {
+	atomic.StoreInt32(&c.cancelled_, 1)
+}
+
+func (c *SearchControl) Cancelled() bool {
+	return atomic.LoadInt32(&c.cancelled_) != 0
+}
+
+// Number of explored states and current depth of the search
+func (c *SearchControl) Progress() (states int, depth int) {
+	return int(atomic.LoadInt64(&c.states_)), int(atomic.LoadInt64(&c.depth_))
+}
+
+func (c *SearchControl) update(states int, depth int) {
+	atomic.StoreInt64(&c.states_, int64(states))
+	atomic.StoreInt64(&c.depth_, int64(depth))
+}
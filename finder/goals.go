@@ -0,0 +1,20 @@
+package finder
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// A goal searched by SbpBfsFinder, and the closest state found reaching it
+type target struct {
+	name_  string
+	goal_  defs.Goal
+	found_ defs.SeqGameState
+}
+
+// Shortest path found to a named goal (see SbpBfsFinder.AddGoal)
+type GoalResult struct {
+	Name  string
+	Found bool
+
+	// Length of the path (as in SbpBfsFinder.GetResult), and its steps
+	Length   int
+	Solution []defs.Command
+}
@@ -0,0 +1,509 @@
+package finder
+
+import "fmt"
+import "time"
+import "github.com/fatih/color"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/utils"
+
+// Finds the farthest states from the start of a sliding blocks puzzle (on a grid or a voxel grid) in
+// move metric (any number of consecutive steps of the same piece counts as one move), or in the metric
+// given by SetMetric. It also solves puzzles in that metric (see SolvePuzzle).
+//
+// The search is a Dijkstra search over (state, last step) nodes, with a queue of buckets by distance (see
+// utils.BucketQueue): in move metric, a step of the last moved piece costs 0 and a step of any other piece
+// costs 1. The last step is kept as much as the metric needs it (see defs.Metric.Memory). Nodes are
+// processed in distance order, so the first node of a state gives its distance. Pieces are identified by
+// position, so alike pieces are handled as in SbpBfsFinder.
+type MoveExtremalFinder struct {
+
+	// Params
+	limits_   FinderLimits
+	silent_   bool
+	symmetry_ bool
+	metric_   defs.Metric
+	control_  *SearchControl
+
+	// Game settings
+	game_       defs.Playable
+	initState_  defs.SeqGameState
+	symmetries_ []grids.Symmetry
+	voxels_     bool
+
+	// Goals searched by SolvePuzzle, and the node reaching each one
+	targets_   []*target
+	goalNodes_ []*moveNode
+
+	// Algorithm state
+	visitedStates_ map[int][]*moveEntry
+	countStates_   int
+	endStatus_     string
+	duration_      time.Duration
+
+	// Results
+	distance_  int
+	extremals_ []*moveEntry
+	histogram_ map[int]int
+}
+
+// A state, and the nodes reaching it with different last steps
+type moveEntry struct {
+	state_    defs.SeqGameState
+	distance_ int
+	nodes_    []*moveNode
+}
+
+// A state reached moving last the piece whose first cell (in reading order) is 'lastCell', in the
+// direction 'lastDir' (layer, row, col). Unused parts of the last step, for the metric, are -1 and 0.
+type moveNode struct {
+	entry_    *moveEntry
+	lastCell_ int
+	lastDir_  grids.Coords3
+	distance_ int
+
+	// Handle in the search queue: the node distance is final once popped
+	item_ *utils.PQItem[*moveNode]
+
+	// Step reaching the node: moved piece first cell in the previous state, and displacement. The
+	// symmetry takes the state reached by the step to the node state.
+	prev_     *moveNode
+	fromCell_ int
+	dir_      grids.Coords3
+	sym_      grids.Symmetry
+}
+
+// States whose pieces are found by cell, whatever their grid (games.SBPState, games.SB3State). Cells are
+// indexes in reading order.
+type cellState interface {
+	FirstCell(pieceId int, sym grids.Symmetry) int
+	PieceAtCell(cell int) int
+}
+
+func (f *MoveExtremalFinder) SetLimits(maxMoves int, maxStates int) {
+	f.limits_.SetLimits(maxMoves, maxStates)
+}
+func (f *MoveExtremalFinder) SilentMode(b bool) {
+	f.silent_ = b
+}
+
+// Metric of the distances, move metric by default. Its costs must be small: the queue has a bucket for
+// each distance up to the farthest one.
+func (f *MoveExtremalFinder) SetMetric(m defs.Metric) {
+	f.metric_ = m
+}
+
+// Metric of the last search
+func (f *MoveExtremalFinder) Metric() defs.Metric {
+	return f.metric_
+}
+
+// Keeps only one state of each set of states related by the puzzle symmetries (see SBGame.Symmetries):
+// counts and extremal states are given up to symmetry.
+func (f *MoveExtremalFinder) SetSymmetry(b bool) {
+	f.symmetry_ = b
+}
+
+// Publishes the progress of the next searches to c, and stops them when c is cancelled
+func (f *MoveExtremalFinder) SetControl(c *SearchControl) {
+	f.control_ = c
+}
+
+// We want to know the minimum path, in the metric, to any state reaching the goal
+func (f *MoveExtremalFinder) DetectGoal(g defs.Goal) {
+	f.targets_ = nil
+	f.AddGoal("", g)
+}
+
+// Adds a goal to search in the same pass (see SbpBfsFinder.AddGoal)
+func (f *MoveExtremalFinder) AddGoal(name string, g defs.Goal) {
+	f.targets_ = append(f.targets_, &target{name_: name, goal_: g})
+}
+
+// Explores the states reachable from the current state of the game, up to the limits. If a limit is
+// reached, results only cover the states found by then.
+func (f *MoveExtremalFinder) FindExtremals(g defs.Playable) {
+	if !f.silent_ {
+		fmt.Println("Extremals finder v.1.0")
+	}
+	f.search(g, false)
+}
+
+// Searches the shortest paths, in the metric, to the goals. States are processed in distance order, so
+// the search stops as soon as all the goals are reached. Symmetries are not used.
+func (f *MoveExtremalFinder) SolvePuzzle(g defs.Playable) {
+	if !f.silent_ {
+		fmt.Println("Metric finder v.1.0")
+	}
+	f.search(g, true)
+}
+
+func (f *MoveExtremalFinder) search(g defs.Playable, solve bool) {
+	if f.metric_ == nil {
+		f.metric_ = defs.MoveMetric()
+	}
+	f.game_ = g
+	f.initState_ = g.State()
+	f.symmetries_ = nil
+	if sbg, ok := g.(*games.SBGame); ok && f.symmetry_ && !solve {
+		f.symmetries_ = sbg.Symmetries()
+	}
+	_, f.voxels_ = f.initState_.(*games.SB3State)
+	f.goalNodes_ = nil
+	if solve {
+		f.goalNodes_ = make([]*moveNode, len(f.targets_))
+	}
+	f.visitedStates_ = make(map[int][]*moveEntry)
+	f.countStates_ = 0
+	f.distance_ = 0
+	f.extremals_ = nil
+	f.histogram_ = make(map[int]int)
+
+	tStart := time.Now()
+	if _, ok := f.initState_.(cellState); ok {
+		f.exploreTree()
+	} else {
+		f.endStatus_ = "Game not supported: only sliding blocks puzzles."
+	}
+	f.duration_ = time.Since(tStart)
+
+	if !f.silent_ {
+		f.Resume()
+	}
+}
+
+func (f *MoveExtremalFinder) exploreTree() {
+	root, _, _ := f.node(f.initState_, nil)
+	root.distance_ = 0
+
+	// Nodes to process, by distance
+	var queue utils.BucketQueue[*moveNode]
+	root.item_ = queue.Push(root, 0)
+	explored := 0
+	goalsFound := 0
+
+	for queue.Size() > 0 {
+		n, d := queue.Pop()
+		if f.limits_.maxDepth_ > 0 && d > f.limits_.maxDepth_ {
+			f.endStatus_ = "Max depth reached."
+			return
+		}
+		explored++
+
+		if f.control_ != nil {
+			if f.control_.Cancelled() {
+				f.endStatus_ = "Search cancelled."
+				return
+			}
+			f.control_.update(explored, d)
+		}
+
+		// First node of the state: the state distance
+		if n.entry_.distance_ < 0 {
+			n.entry_.distance_ = d
+			f.histogram_[d]++
+			if d > f.distance_ {
+				f.distance_ = d
+				f.extremals_ = f.extremals_[:0]
+			}
+			f.extremals_ = append(f.extremals_, n.entry_)
+
+			for i, goal := range f.goalNodes_ {
+				if goal == nil && f.targets_[i].goal_.Reached(n.entry_.state_) {
+					f.goalNodes_[i] = n
+					goalsFound++
+				}
+			}
+			if goalsFound > 0 && goalsFound == len(f.goalNodes_) {
+				f.endStatus_ = "Goals reached."
+				return
+			}
+		}
+
+		state := n.entry_.state_.(cellState)
+		prev := f.lastStep(n, state)
+		f.game_.SetState(n.entry_.state_)
+		for _, mov := range f.game_.ValidMovementsBFS(nil) {
+			fromCell := state.FirstCell(mov.PieceId(), grids.IDENTITY)
+
+			f.game_.Move(mov)
+			newState := f.game_.State()
+			f.game_.UndoMove(mov)
+
+			cost := f.metric_.Cost(prev, mov)
+			m, sym, isNew := f.node(newState, mov)
+			if m == nil {
+				f.endStatus_ = "Max states reached."
+				return
+			}
+
+			// Processed nodes already have their distance
+			if isNew || m.item_.Waiting() && d+cost < m.distance_ {
+				m.distance_ = d + cost
+				m.prev_ = n
+				m.fromCell_ = fromCell
+				m.dir_ = direction(mov, grids.IDENTITY)
+				m.sym_ = sym
+
+				if isNew {
+					m.item_ = queue.Push(m, m.distance_)
+				} else {
+					queue.DecreaseKey(m.item_, m.distance_)
+				}
+			}
+		}
+	}
+	f.endStatus_ = "All states explored, no more states in queue"
+}
+
+// Node of the state with that last step (nil if none), the symmetry taking the state to the node state,
+// and whether the node is new. Nil if the state is new and the max number of states is reached.
+func (f *MoveExtremalFinder) node(s defs.SeqGameState, mov defs.Command) (*moveNode, grids.Symmetry, bool) {
+	h := stateHash(s, f.symmetries_)
+
+	var entry *moveEntry
+	sym := grids.IDENTITY
+	for _, e := range f.visitedStates_[h] {
+		if e.state_.Equal(s) {
+			entry = e
+			break
+		}
+		if x, ok := symmetryBetween(e.state_, s, f.symmetries_); ok {
+			entry, sym = e, x
+			break
+		}
+	}
+	if entry == nil {
+		if f.limits_.maxStates_ > 0 && f.countStates_ >= f.limits_.maxStates_ {
+			return nil, sym, false
+		}
+		entry = &moveEntry{state_: s, distance_: -1}
+		f.visitedStates_[h] = append(f.visitedStates_[h], entry)
+		f.countStates_++
+	}
+
+	// The last step in the node state, as far as the metric needs it
+	lastCell, dir := -1, grids.Coords3{}
+	if mov != nil && f.metric_.Memory() != defs.NO_MEMORY {
+		lastCell = s.(cellState).FirstCell(mov.PieceId(), sym)
+		if f.metric_.Memory() == defs.DIRECTION_MEMORY {
+			dir = direction(mov, sym)
+		}
+	}
+
+	for _, n := range entry.nodes_ {
+		if n.lastCell_ == lastCell && n.lastDir_ == dir {
+			return n, sym, false
+		}
+	}
+	n := &moveNode{entry_: entry, lastCell_: lastCell, lastDir_: dir}
+	entry.nodes_ = append(entry.nodes_, n)
+	return n, sym, true
+}
+
+// The last step of the node, as far as the metric needs it, with the piece id it has in the node state.
+// Nil if none.
+func (f *MoveExtremalFinder) lastStep(n *moveNode, s cellState) defs.Command {
+	if n.lastCell_ < 0 {
+		return nil
+	}
+	return f.step(s.PieceAtCell(n.lastCell_), n.lastDir_)
+}
+
+// Step of the piece in the direction (layer, row, col), as the game moves: GridMov3 on voxel grids,
+// GridMov2 otherwise
+func (f *MoveExtremalFinder) step(pieceId int, dir grids.Coords3) defs.Command {
+	if f.voxels_ {
+		return grids.NewGridMov3(pieceId, dir[0], dir[1], dir[2])
+	}
+	return grids.NewGridMov2(pieceId, dir[1], dir[2])
+}
+
+// Direction (layer, row, col) of the step, taken by the symmetry
+func direction(mov defs.Command, sym grids.Symmetry) grids.Coords3 {
+	if m, ok := mov.(defs.Translation3); ok {
+		dLayer, dRow, dCol := m.Translation()
+		return grids.Coords3{dLayer, dRow, dCol}
+	}
+	dRow, dCol := sym.Translation(mov.(defs.Translation).Translation())
+	return grids.Coords3{0, dRow, dCol}
+}
+
+// Distance of the farthest states found by the last search, in its metric
+func (f *MoveExtremalFinder) Distance() int {
+	return f.distance_
+}
+
+// States at the farthest distance
+func (f *MoveExtremalFinder) Extremals() []defs.SeqGameState {
+	var states []defs.SeqGameState
+	for _, e := range f.extremals_ {
+		states = append(states, e.state_)
+	}
+	return states
+}
+
+// Number of states at each distance
+func (f *MoveExtremalFinder) Histogram() map[int]int {
+	h := make(map[int]int, len(f.histogram_))
+	for d, n := range f.histogram_ {
+		h[d] = n
+	}
+	return h
+}
+
+// Steps of a shortest path (in the metric) from the start to the first extremal state
+func (f *MoveExtremalFinder) ExamplePath() []defs.Command {
+	if len(f.extremals_) == 0 {
+		return nil
+	}
+
+	// A node at the state distance
+	for _, n := range f.extremals_[0].nodes_ {
+		if !n.item_.Waiting() && n.distance_ == f.distance_ {
+			return f.pathTo(n)
+		}
+	}
+	return nil
+}
+
+// Shortest path, in the metric, to each goal of the last SolvePuzzle, in the order they were added
+func (f *MoveExtremalFinder) GoalResults() []GoalResult {
+	var results []GoalResult
+	for i, n := range f.goalNodes_ {
+		r := GoalResult{Name: f.targets_[i].name_}
+		if n != nil {
+			r.Found = true
+			r.Length = n.distance_
+			r.Solution = f.pathTo(n)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// Returns if found, and the length in the metric of the solution to the closest goal
+func (f *MoveExtremalFinder) GetResult() (found bool, length int, dur time.Duration) {
+	if n := f.closestGoal(); n != nil {
+		return true, n.distance_, f.duration_
+	}
+	return false, 0, 0
+}
+
+// Steps of the solution to the closest goal found by the last SolvePuzzle. Nil if not found.
+func (f *MoveExtremalFinder) Solution() []defs.Command {
+	if n := f.closestGoal(); n != nil {
+		return f.pathTo(n)
+	}
+	return nil
+}
+
+// Node of the first goal reached, nil if none
+func (f *MoveExtremalFinder) closestGoal() *moveNode {
+	var closest *moveNode
+	for _, n := range f.goalNodes_ {
+		if n != nil && (closest == nil || n.distance_ < closest.distance_) {
+			closest = n
+		}
+	}
+	return closest
+}
+
+// Steps of the path from the start to the node
+func (f *MoveExtremalFinder) pathTo(last *moveNode) []defs.Command {
+	var reversed []*moveNode
+	for n := last; n != nil && n.prev_ != nil; n = n.prev_ {
+		reversed = append(reversed, n)
+	}
+
+	// Replay the steps: with alike pieces, the piece ids at each position depend on the path. Steps
+	// are given on the node states, 'sym' takes them to the states actually reached.
+	var path []defs.Command
+	sym := grids.IDENTITY
+	f.game_.SetState(f.initState_)
+	for i := len(reversed) - 1; i >= 0; i-- {
+		n := reversed[i]
+		dir := n.dir_
+		dir[1], dir[2] = sym.Translation(dir[1], dir[2])
+		mov := f.step(f.game_.State().(cellState).PieceAtCell(f.symmetricCell(n.fromCell_, sym)), dir)
+
+		f.game_.Move(mov)
+		path = append(path, mov)
+		sym = sym.Compose(n.sym_)
+	}
+	f.game_.SetState(f.initState_)
+	return path
+}
+
+// Cell the symmetry takes the cell to. Symmetries are only used on 2D boards (see SetSymmetry).
+func (f *MoveExtremalFinder) symmetricCell(cell int, sym grids.Symmetry) int {
+	if sym == grids.IDENTITY {
+		return cell
+	}
+	grid := f.initState_.(*games.SBPState).Grid()
+	rows := grid.Rows()
+	cols := grid.Cols()
+	r, c := sym.Cell(rows, cols, cell/cols, cell%cols)
+	return r*cols + c
+}
+
+// Symmetries used by the last search (see SetSymmetry)
+func (f *MoveExtremalFinder) Symmetries() []grids.Symmetry {
+	return f.symmetries_
+}
+
+// Number of different states reached by the last search
+func (f *MoveExtremalFinder) StatesCount() int {
+	return f.countStates_
+}
+
+// Reason why the last search stopped
+func (f *MoveExtremalFinder) EndStatus() string {
+	return f.endStatus_
+}
+
+// Prints statistics and results
+func (f *MoveExtremalFinder) Resume() {
+	headers := color.New(color.FgCyan, color.Bold)
+	out := color.New(color.FgWhite)
+
+	headers.Println("\n - Condition: ", f.endStatus_)
+	headers.Println("\n[STATS]")
+	out.Printf("\n States: %d", f.countStates_)
+	if len(f.symmetries_) > 0 {
+		out.Printf("\n Symmetries: %v", f.symmetries_)
+	}
+	out.Printf("\n Duration: %v", f.duration_)
+	out.Printf("\n Distance histogram:")
+	for d := 0; d <= f.distance_; d++ {
+		out.Printf("\n [%d]: %d", d, f.histogram_[d])
+	}
+
+	if len(f.goalNodes_) > 0 {
+		headers.Println("\n\n[SOLUTION]")
+		for _, r := range f.GoalResults() {
+			if r.Found {
+				out.Printf("\n %s: %d (%s metric)", r.Name, r.Length, f.metric_.Name())
+			} else {
+				out.Printf("\n %s: not found", r.Name)
+			}
+		}
+	}
+
+	headers.Printf("\n\n[EXTREMAL STATES] Distance: %d (%s metric), found: %d\n", f.distance_, f.metric_.Name(), len(f.extremals_))
+	if len(f.extremals_) > 0 {
+		f.extremals_[0].state_.TinyGoPrint()
+		out.Printf("\n Path:")
+		for _, m := range f.ExamplePath() {
+			d := direction(m, grids.IDENTITY)
+			if f.voxels_ {
+				out.Printf(" [%d, %d, %d, %d]", m.PieceId(), d[0], d[1], d[2])
+			} else {
+				out.Printf(" [%d, %d, %d]", m.PieceId(), d[1], d[2])
+			}
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,127 @@
+package finder
+
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Farthest states in move metric: consecutive steps of a piece are one move.
+func TestMoveExtremals(t *testing.T) {
+	extremals := func(board grids.Matrix2d) *MoveExtremalFinder {
+		var f MoveExtremalFinder
+		f.SilentMode(true)
+		f.SetLimits(300, 0)
+		f.FindExtremals(newGame(board))
+		return &f
+	}
+
+	// One move reaches any cell of the row
+	f := extremals(grids.Matrix2d{[]int{1, 0, 0}})
+	if f.Distance() != 1 || len(f.Extremals()) != 2 || f.Histogram()[0] != 1 || f.Histogram()[1] != 2 {
+		t.Errorf("Row: distance %d, extremals %d, histogram %v", f.Distance(), len(f.Extremals()), f.Histogram())
+	}
+
+	f = extremals(pennant)
+	if f.EndStatus() != "All states explored, no more states in queue" {
+		t.Fatalf("Pennant not fully explored: %s", f.EndStatus())
+	}
+
+	total := 0
+	for d, n := range f.Histogram() {
+		if d > f.Distance() {
+			t.Errorf("Distance %d beyond the farthest one", d)
+		}
+		total += n
+	}
+	if total != f.StatesCount() {
+		t.Errorf("Histogram counts %d states, found %d", total, f.StatesCount())
+	}
+
+	// The example path reaches an extremal state in exactly Distance() moves
+	path := f.ExamplePath()
+	moves := 0
+	for i, m := range path {
+		if i == 0 || m.PieceId() != path[i-1].PieceId() {
+			moves++
+		}
+	}
+	if moves != f.Distance() {
+		t.Errorf("Example path has %d moves, distance is %d", moves, f.Distance())
+	}
+	if !replay(newGame(pennant), path).Equal(f.Extremals()[0]) {
+		t.Errorf("Example path does not reach the extremal state")
+	}
+
+	// Without limits, the whole space is explored
+	var u MoveExtremalFinder
+	u.SilentMode(true)
+	u.FindExtremals(newGame(grids.Matrix2d{
+		[]int{1, 0},
+		[]int{0, 0},
+	}))
+	if u.Distance() != 1 || u.StatesCount() != 4 || u.EndStatus() != "All states explored, no more states in queue" {
+		t.Errorf("No limits: distance %d, %d states, %s", u.Distance(), u.StatesCount(), u.EndStatus())
+	}
+}
+
+// Distances depend on the metric: steps, moves, straight moves or weighted moves.
+func TestMetrics(t *testing.T) {
+	path := []defs.Command{
+		grids.NewGridMov2(1, 0, 1),
+		grids.NewGridMov2(1, 0, 1),
+		grids.NewGridMov2(1, 1, 0),
+		grids.NewGridMov2(2, -1, 0),
+	}
+	weighted := defs.WeightedMetric(defs.MoveMetric(), map[int]int{2: 5})
+	for _, c := range []struct {
+		metric defs.Metric
+		length int
+	}{
+		{defs.StepMetric(), 4},
+		{defs.MoveMetric(), 2},
+		{defs.StraightLineMetric(), 3},
+		{weighted, 6},
+	} {
+		if l := defs.PathLength(c.metric, path); l != c.length {
+			t.Errorf("Path length in %s metric: %d, expected %d", c.metric.Name(), l, c.length)
+		}
+	}
+
+	// Farthest cells of a 2x2 board
+	for _, c := range []struct {
+		metric    defs.Metric
+		distance  int
+		extremals int
+	}{
+		{defs.StepMetric(), 2, 1},
+		{defs.MoveMetric(), 1, 3},
+		{defs.StraightLineMetric(), 2, 1},
+		{defs.WeightedMetric(defs.MoveMetric(), map[int]int{1: 3}), 3, 3},
+	} {
+		g := newGame(grids.Matrix2d{
+			[]int{1, 0},
+			[]int{0, 0},
+		})
+
+		var f MoveExtremalFinder
+		f.SilentMode(true)
+		f.SetLimits(10, 0)
+		f.SetMetric(c.metric)
+		f.FindExtremals(g)
+		if f.Distance() != c.distance || len(f.Extremals()) != c.extremals {
+			t.Errorf("%s metric: distance %d, extremals %d", c.metric.Name(), f.Distance(), len(f.Extremals()))
+		}
+		if l := defs.PathLength(c.metric, f.ExamplePath()); l != f.Distance() {
+			t.Errorf("%s metric: example path length %d, distance %d", c.metric.Name(), l, f.Distance())
+		}
+
+		// The opposite corner is the farthest cell in every metric
+		f.DetectGoal(games.PieceAt(1, 1, 1))
+		f.SolvePuzzle(g)
+		found, length, _ := f.GetResult()
+		if !found || length != c.distance || defs.PathLength(c.metric, f.Solution()) != length {
+			t.Errorf("%s metric: solution length %d, should be %d", c.metric.Name(), length, c.distance)
+		}
+	}
+}
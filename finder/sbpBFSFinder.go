@@ -4,8 +4,8 @@ import "fmt"
 import "time"
 import "github.com/fatih/color"
 
-import "github.com/edgarweto/puzzlopia/puzzle-solvers/utils"
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/search"
 
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
 
@@ -18,31 +18,34 @@ type SbpBfsFinder struct {
 	limits_       FinderLimits
 	silent_       bool
 	hardOptimals_ bool
+	symmetry_     bool
+	control_      *SearchControl
 
 	// Game settings
-	game_      defs.Playable
-	initState_ defs.SeqGameState
+	game_       defs.Playable
+	initState_  defs.SeqGameState
+	symmetries_ []grids.Symmetry
 
-	// If we are searching for a concrete state
-	search_     *games.SBPState
-	foundState_ *defs.SeqGameState
+	// If we are searching for concrete states: the goals, and the closest state found
+	targets_      []*target
+	targetsFound_ int
+	stopAfter_    int
+	foundState_   *defs.SeqGameState
 
-	// If we are searching for a concrete state
-	extremals_     []defs.SeqGameState
-	extremalDist_  int
-	findExtremals_ bool
-
-	// Stats
-	countStates_  utils.ScalarStatistic
-	nodesDegree_  utils.ScalarStatistic
-	frontierSize_ utils.RangeStatistic
+	// If we are searching for the farthest states
+	extremals_    []defs.SeqGameState
+	extremalDist_ int
 
 	// Algorithm state
-	visitedStates_ map[int][]defs.SeqGameState
-	frontier_      utils.Queue
-	nextFrontier_  []defs.SeqGameState
-	endStatus_     string
-	duration_      time.Duration
+	engine_    *search.Engine[defs.SeqGameState, defs.Command]
+	endStatus_ string
+	duration_  time.Duration
+
+	// State being processed, with its hash and path taken before an equivalency may replace the path
+	// (see prepare)
+	next_     defs.SeqGameState
+	nextHash_ int
+	chain_    *defs.MovChain
 
 	debug_         bool
 	debugPath_     [][]int
@@ -71,15 +74,41 @@ func (f *SbpBfsFinder) SilentMode(b bool) {
 	f.silent_ = b
 }
 
-// We want to know the minimum path to this state
-func (f *SbpBfsFinder) Detect(m *grids.Matrix2d) (err error) {
+// Keeps only one state of each set of states related by the puzzle symmetries (see SBGame.Symmetries).
+// Solutions reaching the image of a goal are mapped back to the goal.
+func (f *SbpBfsFinder) SetSymmetry(b bool) {
+	f.symmetry_ = b
+}
 
-	f.search_ = &games.SBPState{}
-	f.search_.Init(m)
+// Publishes the progress of the next searches to c, and stops them when c is cancelled
+func (f *SbpBfsFinder) SetControl(c *SearchControl) {
+	f.control_ = c
+}
 
+// We want to know the minimum path to this state: non-zero cells of m must match (see games.Template)
+func (f *SbpBfsFinder) Detect(m *grids.Matrix2d) (err error) {
+	return f.DetectGoal(games.Template(m))
+}
+
+// We want to know the minimum path to any state reaching the goal (see the games package goals)
+func (f *SbpBfsFinder) DetectGoal(g defs.Goal) (err error) {
+	f.targets_ = nil
+	f.AddGoal("", g)
 	return nil
 }
 
+// Adds a goal to search in the same pass: the shortest path to each one is reported by GoalResults,
+// while GetResult and Solution give the closest one.
+func (f *SbpBfsFinder) AddGoal(name string, g defs.Goal) {
+	f.targets_ = append(f.targets_, &target{name_: name, goal_: g})
+}
+
+// Stops the search as soon as n goals are reached (0, the default, searches until the limits). Paths
+// are the shortest found by then: with the move metric, a later one could still be shorter.
+func (f *SbpBfsFinder) StopAfterGoals(n int) {
+	f.stopAfter_ = n
+}
+
 // Useful to run the algorithm silently until we reach a concrete path. Then, debug mode is
 // activated for some amount of steps.
 func (f *SbpBfsFinder) DebugPath(path [][]int) {
@@ -94,17 +123,57 @@ func (f *SbpBfsFinder) GetResult() (found bool, cr int, dur time.Duration) {
 	return true, (*f.foundState_).CollapsedPathLen(), f.duration_
 }
 
+// Steps of the solution found by the last search, from the initial state. Nil if not found.
+func (f *SbpBfsFinder) Solution() []defs.Command {
+	if f.foundState_ == nil {
+		return nil
+	}
+	return append([]defs.Command{}, (*f.foundState_).PathChain()...)
+}
+
+// Shortest path found to each goal, in the order they were added
+func (f *SbpBfsFinder) GoalResults() []GoalResult {
+	var results []GoalResult
+	for _, t := range f.targets_ {
+		r := GoalResult{Name: t.name_}
+		if t.found_ != nil {
+			r.Found = true
+			r.Length = t.found_.CollapsedPathLen()
+			r.Solution = append([]defs.Command{}, t.found_.PathChain()...)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// Number of different states stored by the last search
+func (f *SbpBfsFinder) StatesCount() int {
+	if f.engine_ == nil {
+		return 0
+	}
+	return f.engine_.StatesCount()
+}
+
+// Farthest states found by FindExtremals, and their distance from the initial state
+func (f *SbpBfsFinder) Extremals() (dist int, states []defs.SeqGameState) {
+	return f.extremalDist_, f.extremals_
+}
+
+// Reason why the last search stopped
+func (f *SbpBfsFinder) EndStatus() string {
+	return f.endStatus_
+}
+
 // Prints statistics and results
 func (f *SbpBfsFinder) Resume() {
 
 	f.fmtHeaders_.Println("\n - Condition: ", f.endStatus_)
 
 	f.fmtHeaders_.Println("\n[STATS]")
-	f.countStates_.Resume(f.outDbg2_)
-	f.nodesDegree_.ResumeAv(f.outDbg2_)
-	f.frontierSize_.ResumeRange(f.outDbg2_)
+	f.engine_.Resume(f.outDbg2_)
+	f.resumeSymmetries()
 
-	if f.search_ != nil {
+	if len(f.targets_) > 0 {
 		f.fmtHeaders_.Println("\n\n[SOLUTION]\n")
 
 		search := color.New(color.FgYellow, color.Bold)
@@ -116,162 +185,199 @@ func (f *SbpBfsFinder) Resume() {
 		} else {
 			search.Println("Not found.")
 		}
+
+		// Several goals: path len of each one
+		if len(f.targets_) > 1 {
+			for _, r := range f.GoalResults() {
+				if r.Found {
+					f.outDbg2_.Printf("\n %s: %d", r.Name, r.Length)
+				} else {
+					f.outDbg2_.Printf("\n %s: not found", r.Name)
+				}
+			}
+		}
 	}
 	fmt.Println("\n\n")
 }
 
 /**
- * @summary Makes a depth-search and returns the most 'distant' states.
+ * @summary Makes a breadth-first search of the closest states reaching the goals.
  *
  * @param {defs.Playable} g The sequential game
- * @param {int} maxSteps Max depth of the search
- * @param {int} maxExtremals Max number of extremal states returned
  */
 func (f *SbpBfsFinder) SolvePuzzle(g defs.Playable) {
 
 	if !f.silent_ {
 		fmt.Println("Puzzle Finder v.1.0")
 	}
+	f.run(g, search.SOLVE)
+
+	if !f.silent_ {
+		f.Resume()
+	}
+}
+
+// Searches the game with the search engine, in the mode. States equal under the symmetries are the same
+// state, and a revisit may give a shorter path to the old state (see revisit).
+func (f *SbpBfsFinder) run(g defs.Playable, mode search.Mode) {
 	f.fmtHeaders_ = color.New(color.FgCyan, color.Bold)
 
 	f.outDbg1_ = color.New(color.FgCyan)
 	f.outDbg2_ = color.New(color.FgWhite)
 	f.outDbg3_ = color.New(color.FgYellow)
 
-	f.countStates_.Set("States")
-	f.nodesDegree_.Set("Node degree")
-	f.frontierSize_.Set("Frontier size")
-
 	f.game_ = g
-	f.visitedStates_ = make(map[int][]defs.SeqGameState)
 	f.initState_ = f.game_.State()
+	f.initSymmetries()
 
-	h := f.initState_.ToHash()
-	f.visitedStates_[h] = append(f.visitedStates_[h], f.initState_)
-	f.addToFrontier(f.initState_)
-	f.countStates_.Incr()
+	e := &search.Engine[defs.SeqGameState, defs.Command]{}
+	e.SetLimits(f.limits_.maxDepth_, f.limits_.maxStates_)
+	e.SetEquivalence(func(s defs.SeqGameState) int {
+		if s == f.next_ {
+			return f.nextHash_
+		}
+		return stateHash(s, f.symmetries_)
+	}, func(st defs.SeqGameState, s defs.SeqGameState) bool {
+		if st.Equal(s) {
+			return true
+		}
+		_, ok := symmetryBetween(st, s, f.symmetries_)
+		return ok
+	})
+	e.SetGoal(f.reachesGoal)
+	e.OnGoal(func(n *search.Node[defs.SeqGameState, defs.Command]) bool {
+		f.checkGoals(n.State())
+		return false
+	})
+	e.OnState(func(n *search.Node[defs.SeqGameState, defs.Command]) {
+		f.addToFrontier(n.State())
+	})
+	e.OnRevisit(f.revisit)
+	e.OnExpand(f.expanding)
+	f.engine_ = e
 
 	tStart := time.Now()
 	if !f.silent_ {
 		f.fmtHeaders_.Println("\n[WORKING]...")
 	}
 
-	f.exploreTree()
+	e.Run(&sbpSearchGame{f}, mode)
+	f.endStatus_ = e.EndStatus()
 
 	tEnd := time.Now()
 	f.duration_ = tEnd.Sub(tStart)
 	if !f.silent_ {
 		f.fmtHeaders_.Println("\n[DONE] ", f.duration_)
 	}
-
-	if !f.silent_ {
-		f.Resume()
-	}
 }
 
-// Implements the BFS algorithm. Uses a priority queue to save pending nodes to be visited; explores one node at a time.
-func (f *SbpBfsFinder) exploreTree() {
-
-	statesCount := 1
+// The game as seen by the search engine: the movements from a state follow the trajectory of the last
+// moved piece, and the states they reach get their paths before the engine looks them up.
+type sbpSearchGame struct {
+	f_ *SbpBfsFinder
+}
 
-	curState := f.popFrontier()
-	for curState != nil {
+func (g *sbpSearchGame) Start() defs.SeqGameState {
+	return g.f_.initState_
+}
 
-		statesCount++
-		if f.limits_.maxStates_ > 0 && statesCount >= f.limits_.maxStates_ {
-			f.endStatus_ = "Max states reached."
-			break
-		} else if curState.Depth() >= f.limits_.maxDepth_ {
-			f.endStatus_ = "Max depth reached."
-			break
-		}
+func (g *sbpSearchGame) Expand(curState defs.SeqGameState, yield func(defs.Command, defs.SeqGameState) bool) {
+	f := g.f_
 
-		if !f.silent_ {
-			update := f.limits_.maxStates_ / 10
-			if f.limits_.maxStates_ > 0 && statesCount%update == 0 {
-				pct := (100 * statesCount / f.limits_.maxStates_)
-				fmt.Printf("\n%d%%", pct)
-			}
-		}
-		var reversePath []defs.Command
-		curState.BuildPathReversed(&reversePath)
+	var pieceTrajectory grids.GridPath2
+	pieceTrajectory.BuildFromReversePath(curState.ParentPath().LastPieceMovs())
 
-		// Debug detect path:
-		if f.debugTemp_ && f.debugPath_ != nil {
-			f.debug_ = false
-		}
+	f.game_.SetState(curState)
+	validMovs := f.game_.ValidMovementsBFS(pieceTrajectory.Path())
 
-		if f.debugPath_ != nil {
-			//curPath := grids.GridPath2{reversePath}
-			curPath := grids.PathFromSlice(reversePath)
-			if curPath.IsEquivalent(f.debugPath_, true) {
-				// Ok, activate temporal debug:
-				f.debug_ = true
-				f.debugSteps_ = 0
-				f.maxDebugSteps_ = 30
-			}
-		}
-		if curState.MarkedToDebug() {
-			f.debug_ = true
-		}
-		if f.debug_ {
-			f.debugSteps_++
-		}
-		if f.debugTemp_ && f.debugPath_ != nil && f.debugSteps_ >= f.maxDebugSteps_ {
-			f.debug_ = false
-		}
+	if f.debug_ {
+		f.outDbg2_.Printf("	 Valid movs:%v", len(validMovs))
+	}
 
-		var pieceTrajectory grids.GridPath2
-		pieceTrajectory.BuildFromReversePath(reversePath)
+	for _, mov := range validMovs {
 
-		f.game_.SetState(curState)
-		validMovs := f.game_.ValidMovementsBFS(pieceTrajectory.Path())
+		f.game_.Move(mov)
+		newState := f.game_.State()
+		newState.SetPrevState(curState, mov)
 
-		f.nodesDegree_.Add(len(validMovs))
+		f.prepare(newState, mov)
+		next := yield(mov, newState)
 
-		if f.debug_ {
-			f.outDbg2_.Printf("	 Valid movs:%v", len(validMovs))
+		f.game_.UndoMove(mov)
+		if !next {
+			return
 		}
+	}
+}
 
-		frontierSize := f.frontier_.Size()
-
-		for _, mov := range validMovs {
-
-			f.game_.Move(mov)
-			newState := f.game_.State()
-			newState.SetPrevState(curState, mov)
-
-			f.processState(newState, reversePath, mov)
+// Called before expanding each state: returns why the search should stop, if it should.
+func (f *SbpBfsFinder) expanding(n *search.Node[defs.SeqGameState, defs.Command]) string {
+	curState := n.State()
+	f.popFrontier(curState)
 
-			f.game_.UndoMove(mov)
+	if f.stopAfter_ > 0 && f.targetsFound_ >= f.stopAfter_ {
+		return "Goals reached."
+	}
+	statesCount := f.engine_.Counted()
+	if f.control_ != nil {
+		if f.control_.Cancelled() {
+			return "Search cancelled."
 		}
+		f.control_.update(statesCount, curState.Depth())
+	}
 
-		// Detect extremal states:
-		if f.findExtremals_ && f.frontier_.Size() == frontierSize {
-			f.addExtremalState(curState)
+	if !f.silent_ {
+		update := f.limits_.maxStates_ / 10
+		if f.limits_.maxStates_ > 0 && statesCount%update == 0 {
+			pct := (100 * statesCount / f.limits_.maxStates_)
+			fmt.Printf("\n%d%%", pct)
 		}
+	}
+	// Debug detect path:
+	if f.debugTemp_ && f.debugPath_ != nil {
+		f.debug_ = false
+	}
 
-		// Let's visite next pending state
-		curState = f.popFrontier()
+	if f.debugPath_ != nil {
+		var reversePath []defs.Command
+		curState.BuildPathReversed(&reversePath)
+
+		//curPath := grids.GridPath2{reversePath}
+		curPath := grids.PathFromSlice(reversePath)
+		if curPath.IsEquivalent(f.debugPath_, true) {
+			// Ok, activate temporal debug:
+			f.debug_ = true
+			f.debugSteps_ = 0
+			f.maxDebugSteps_ = 30
+		}
+	}
+	if curState.MarkedToDebug() {
+		f.debug_ = true
+	}
+	if f.debug_ {
+		f.debugSteps_++
 	}
-	if curState == nil {
-		f.endStatus_ = "All states explored, no more states in queue"
+	if f.debugTemp_ && f.debugPath_ != nil && f.debugSteps_ >= f.maxDebugSteps_ {
+		f.debug_ = false
 	}
+	return ""
 }
 
-// Checks whether the state is new or have been previously processed.
-// If it isn't new, then compares the path length and decides if it is worth re-visiting it.
-func (f *SbpBfsFinder) processState(s defs.SeqGameState, reversePath []defs.Command, mov defs.Command) {
+// Sets the path of a new state: the path of its previous state, or a shorter one if the previous state
+// has an equivalency. The state is looked up by its hash before the path change.
+func (f *SbpBfsFinder) prepare(s defs.SeqGameState, mov defs.Command) {
 	if f.debugTemp_ {
 		s.MarkToDebug()
 	}
 
-	h := s.ToHash()
+	h := stateHash(s, f.symmetries_)
+	f.next_, f.nextHash_ = s, h
 	if f.debug_ {
 		f.outDbg1_.Printf("\n	 - Process state. Hash: %d, MOV: %v", h, mov)
 	}
 
-	chain := f.reversePathOn(reversePath, mov)
+	// The path following the previous states, shared with them
+	chain := s.ParentPath()
 	s.SetMovChain(chain, nil)
 
 	// Stop!
@@ -282,135 +388,143 @@ func (f *SbpBfsFinder) processState(s defs.SeqGameState, reversePath []defs.Comm
 		if s.PrevState().ApplyEquivalencyContinuity(s, mov, f.initState_) {
 			newLen := s.CollapsedPathLen()
 			if newLen < oldLen {
-				chain = s.PathChain()
+				chain = s.MovChain()
 				if f.debug_ {
 					f.outDbg1_.Printf("\n	 - Equivalency applied: oldLen: %d, newLen: %d", oldLen, newLen)
 				}
 			}
 		}
 	}
+	f.chain_ = chain
+}
 
-	if f.visitedStates_[h] == nil {
+// The state of child has been visited already, in old: compares the path lengths and decides if it is
+// worth re-visiting it.
+func (f *SbpBfsFinder) revisit(old *search.Node[defs.SeqGameState, defs.Command], child *search.Node[defs.SeqGameState, defs.Command]) bool {
+	st, s, mov := old.State(), child.State(), child.Mov()
 
-		// Easy, it is a new state
-		f.countStates_.Incr()
-		f.visitedStates_[h] = append(f.visitedStates_[h], s)
+	if !st.Equal(s) {
+		sym, _ := symmetryBetween(st, s, f.symmetries_)
+		f.revisitSymmetric(st, child, sym)
+		return false
+	}
 
-		// Let's explore its childs later
-		f.addToFrontier(s)
+	// We arrived to the same state from two separate chain of movements.
+	// Update to the shortest path:
+	lenOld := st.CollapsedPathLen()
+	lenNew := s.CollapsedPathLen()
 
-		// If we are searching for a state, check if found:
-		if f.search_ != nil {
-			if s.EqualSub(f.search_) {
-				f.updateObjective(s)
-			}
+	if lenNew < lenOld {
+		// Update!
+		st.CopyMovChainFrom(s)
+
+		if st.IsObjective() || s.IsObjective() {
+			f.checkGoals(st)
 		}
 
-	} else {
-
-		// We still need to compare it with other states to see if it is a new state or not.
-		for _, st := range f.visitedStates_[h] {
-			if st.Equal(s) {
-
-				// We arrived to the same state from two separate chain of movements.
-				// Update to the shortest path:
-				lenOld := st.CollapsedPathLen()
-				lenNew := s.CollapsedPathLen()
-
-				if lenNew < lenOld {
-					// Update!
-					st.CopyMovChainFrom(s)
-
-					if st.IsObjective() || s.IsObjective() {
-						f.updateObjective(st)
-					}
-
-					// Discard s
-					// But if st is not in the frontier, add again!
-					if f.hardOptimals_ {
-						if f.debug_ {
-							f.outDbg1_.Printf("\n	 - Reintroduce to frontier! oldLen: %d, newLen: %d", lenOld, lenNew)
-						}
-						f.addToFrontier(s)
-					}
-				} else if lenNew == lenOld {
-					if f.debug_ {
-						f.outDbg1_.Printf(" (Same as [%d;CR:%d], cur: [%d;CR:%d])", st.Uid(), lenOld, s.Uid(), lenNew)
-					}
-
-					if st.Waiting() {
-						if f.debug_ {
-							f.outDbg1_.Printf(" Add equivalency.")
-						}
-						// Ok st is waiting and still not processed
-
-						// We can add the equivalency if mov is a valid movement on directly on state st.
-						// They are equivalent, but maybe, due to alike pieces, the mov command cannot be performed!
-						//if st.ValidMovement(mov) {
-						st.AddEquivPath(s, chain, mov) //And s is not processed by now...
-						//}
-					} else {
-						// if st.SamePieceMovedNext(mov) {
-						// 	f.addToFrontier(s)
-						// }
-					}
-				}
-				return
+		// Discard s
+		// But if st is not in the frontier, add again!
+		if f.hardOptimals_ {
+			if f.debug_ {
+				f.outDbg1_.Printf("\n	 - Reintroduce to frontier! oldLen: %d, newLen: %d", lenOld, lenNew)
 			}
+			f.requeue(child)
+		}
+	} else if lenNew == lenOld {
+		if f.debug_ {
+			f.outDbg1_.Printf(" (Same as [%d;CR:%d], cur: [%d;CR:%d])", st.Uid(), lenOld, s.Uid(), lenNew)
 		}
 
-		// Ok, new state with hash collision.
-		f.countStates_.Incr()
-		f.visitedStates_[h] = append(f.visitedStates_[h], s)
-
-		// Let's explore its childs later
-		f.addToFrontier(s)
-
-		// If we are searching for a state, check if found:
-		if f.search_ != nil {
-			if s.EqualSub(f.search_) {
-				f.updateObjective(s)
+		if st.Waiting() {
+			if f.debug_ {
+				f.outDbg1_.Printf(" Add equivalency.")
 			}
-		}
+			// Ok st is waiting and still not processed
 
+			// We can add the equivalency if mov is a valid movement on directly on state st.
+			// They are equivalent, but maybe, due to alike pieces, the mov command cannot be performed!
+			//if st.ValidMovement(mov) {
+			st.AddEquivPath(s, f.chain_, mov) //And s is not processed by now...
+			//}
+		} else {
+			// if st.SamePieceMovedNext(mov) {
+			// 	f.addToFrontier(s)
+			// }
+		}
 	}
+	return false
 }
 
-// Push back to the priority queue that state.
+// Marks the state as waiting in the frontier
 func (f *SbpBfsFinder) addToFrontier(s defs.SeqGameState) {
 	if f.debug_ {
 		f.outDbg2_.Printf("\n	  Add to frontier: state [%d], from move %v", s.Uid(), s.PrevMov())
 	}
-	f.frontier_.PushBack(s)
-	f.frontierSize_.Add(f.frontier_.Size())
 	s.SetWaiting(true)
 }
 
-// Pop from start of queue (highest priority)
-func (f *SbpBfsFinder) popFrontier() defs.SeqGameState {
+// Pushes the node to the frontier again, to expand it with its new path
+func (f *SbpBfsFinder) requeue(n *search.Node[defs.SeqGameState, defs.Command]) {
+	f.addToFrontier(n.State())
+	f.engine_.Push(n)
+}
 
-	x := f.frontier_.PopFront()
-	if x != nil {
-		s := x.(*games.SBPState)
-		if s != nil {
-			(*s).SetWaiting(false)
+// The state leaves the frontier to be expanded
+func (f *SbpBfsFinder) popFrontier(s defs.SeqGameState) {
+	s.SetWaiting(false)
 
-			if f.debug_ {
-				f.outDbg2_.Printf("\n\n### Explore state [%d]", (*s).Uid())
-				(*s).TinyPrint()
+	if f.debug_ {
+		f.outDbg2_.Printf("\n\n### Explore state [%d]", s.Uid())
+		s.TinyPrint()
+	}
+}
+
+// True if the state, or one of its images by the symmetries, reaches a goal
+func (f *SbpBfsFinder) reachesGoal(s defs.SeqGameState) bool {
+	for _, t := range f.targets_ {
+		if t.goal_.Reached(s) {
+			return true
+		}
+		for _, sym := range f.symmetries_ {
+			if t.goal_.Reached(s.(*games.SBPState).Transformed(sym)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Checks the goals reached by the state, or by its images by the symmetries
+func (f *SbpBfsFinder) checkGoals(s defs.SeqGameState) {
+	for _, t := range f.targets_ {
+		if t.goal_.Reached(s) {
+			f.updateObjective(t, s)
+			continue
+		}
+
+		for _, sym := range f.symmetries_ {
+			if t.goal_.Reached(s.(*games.SBPState).Transformed(sym)) {
+				s.MarkAsObjective()
+				f.updateObjective(t, f.image(s, sym))
+				break
 			}
 		}
-		return s
 	}
-	return nil
 }
 
-// Called every time we found the objective state (solution of puzzle). We check whether the new
+// Called every time we found an objective state (solution of puzzle). We check whether the new
 // solution is better or not.
-func (f *SbpBfsFinder) updateObjective(s defs.SeqGameState) {
+func (f *SbpBfsFinder) updateObjective(t *target, s defs.SeqGameState) {
 
 	s.MarkAsObjective()
 
+	if t.found_ == nil {
+		f.targetsFound_++
+	}
+	if t.found_ == nil || s.CollapsedPathLen() < t.found_.CollapsedPathLen() {
+		t.found_ = s
+	}
+
 	curPathLen := 0
 	if f.foundState_ != nil {
 		curPathLen = (*f.foundState_).CollapsedPathLen()
@@ -426,61 +540,22 @@ func (f *SbpBfsFinder) updateObjective(s defs.SeqGameState) {
 	}
 }
 
-func (f *SbpBfsFinder) reversePathOn(path []defs.Command, mov defs.Command) []defs.Command {
-	result := make([]defs.Command, len(path)+1)
-
-	l := len(path) - 1
-	for i := l; i >= 0; i-- {
-		result[l-i] = path[i]
-	}
-	result[len(path)] = mov
-	return result
-}
-
 /**
- * @summary Makes a depth-search and returns the most 'distant' states.
+ * @summary Makes a breadth-first search and returns the most 'distant' states.
  *
  * @param {defs.Playable} g The sequential game
- * @param {int} maxSteps Max depth of the search
- * @param {int} maxExtremals Max number of extremal states returned
  */
 func (f *SbpBfsFinder) FindExtremals(g defs.Playable) {
 
-	f.findExtremals_ = true
-
 	if !f.silent_ {
 		fmt.Println("Puzzle Finder v.1.0")
 	}
-	f.fmtHeaders_ = color.New(color.FgCyan, color.Bold)
-
-	f.outDbg1_ = color.New(color.FgCyan)
-	f.outDbg2_ = color.New(color.FgWhite)
-	f.outDbg3_ = color.New(color.FgYellow)
-
-	f.countStates_.Set("States")
-	f.nodesDegree_.Set("Node degree")
-	f.frontierSize_.Set("Frontier size")
-
-	f.game_ = g
-	f.visitedStates_ = make(map[int][]defs.SeqGameState)
-	f.initState_ = f.game_.State()
-
-	h := f.initState_.ToHash()
-	f.visitedStates_[h] = append(f.visitedStates_[h], f.initState_)
-	f.addToFrontier(f.initState_)
-	f.countStates_.Incr()
-
-	tStart := time.Now()
-	if !f.silent_ {
-		f.fmtHeaders_.Println("\n[WORKING]...")
-	}
+	f.run(g, search.EXTREMALS)
 
-	f.exploreTree()
-
-	tEnd := time.Now()
-	f.duration_ = tEnd.Sub(tStart)
-	if !f.silent_ {
-		f.fmtHeaders_.Println("\n[DONE] ", f.duration_)
+	f.extremalDist_ = f.engine_.Distance()
+	f.extremals_ = nil
+	for _, n := range f.engine_.Extremals() {
+		f.extremals_ = append(f.extremals_, n.State())
 	}
 
 	if !f.silent_ {
@@ -494,9 +569,8 @@ func (f *SbpBfsFinder) resumeExtremals() {
 	f.fmtHeaders_.Println("\n - Condition: ", f.endStatus_)
 
 	f.fmtHeaders_.Println("\n[STATS]")
-	f.countStates_.Resume(f.outDbg2_)
-	f.nodesDegree_.ResumeAv(f.outDbg2_)
-	f.frontierSize_.ResumeRange(f.outDbg2_)
+	f.engine_.Resume(f.outDbg2_)
+	f.resumeSymmetries()
 
 	f.fmtHeaders_.Printf("\n\n[EXTREMAL STATES] Found: %d\n", len(f.extremals_))
 
@@ -506,18 +580,3 @@ func (f *SbpBfsFinder) resumeExtremals() {
 
 	fmt.Println("\n\n")
 }
-
-// We are interested in extremal states, those at larger distance from the start state.
-func (f *SbpBfsFinder) addExtremalState(s defs.SeqGameState) {
-
-	if s.Depth() > f.extremalDist_ {
-
-		// Then reset and add the state
-		f.extremalDist_ = s.Depth()
-		f.extremals_ = f.extremals_[:0]
-		f.extremals_ = append(f.extremals_, s)
-	} else if s.Depth() == f.extremalDist_ {
-
-		f.extremals_ = append(f.extremals_, s)
-	}
-}
@@ -0,0 +1,339 @@
+package finder
+
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Pennant: the big piece 2 goes to the bottom left corner in 59 moves
+var pennant = grids.Matrix2d{
+	[]int{2, 2, 1, 1},
+	[]int{2, 2, 3, 3},
+	[]int{5, 4, 0, 0},
+	[]int{6, 7, 8, 8},
+	[]int{6, 7, 9, 9},
+}
+
+// Sliding blocks game on the board, with its alike pieces
+func newGame(board grids.Matrix2d) *games.SBGame {
+	return newLatticeGame(board, grids.SQUARE_LATTICE, games.AUTO_BOARD)
+}
+
+// Sliding blocks game on the board, with its alike pieces, on the lattice and the board kind
+func newLatticeGame(board grids.Matrix2d, lattice grids.Lattice, kind games.BoardKind) *games.SBGame {
+	var g games.SBGame
+	g.SetLattice(lattice)
+	g.Define(&board)
+	g.AutoAlikePieces()
+	g.SetBoardKind(kind)
+	g.Build()
+	return &g
+}
+
+// Silent finder of optimal solutions, with a depth limit beyond the test puzzles
+func newFinder() *SbpBfsFinder {
+	var f SbpBfsFinder
+	f.SilentMode(true)
+	f.SetLimits(300, 0)
+	f.SetHardOptimal(true)
+	return &f
+}
+
+// State reached by playing the path on the game
+func replay(g defs.Playable, path []defs.Command) defs.SeqGameState {
+	for _, m := range path {
+		g.Move(m)
+	}
+	return g.State()
+}
+
+// Goal predicates equivalent to the template give the same solution; a looser goal a shorter one.
+func TestGoalPredicates(t *testing.T) {
+	solve := func(goal defs.Goal) (bool, int) {
+		f := newFinder()
+		f.DetectGoal(goal)
+		f.SolvePuzzle(newGame(pennant))
+
+		found, length, _ := f.GetResult()
+		return found, length
+	}
+
+	if found, length := solve(games.And(games.PieceAt(2, 3, 0), games.PieceAt(2, 4, 1))); !found || length != 59 {
+		t.Errorf("Pennant with piece predicates: found %v, len %d", found, length)
+	}
+
+	// Big piece anywhere in the two bottom rows
+	if found, length := solve(games.Region(2, 3, 0, 4, 3)); !found || length >= 59 {
+		t.Errorf("Pennant with region predicate: found %v, len %d", found, length)
+	}
+}
+
+// Several goals in one pass: each one gets its own shortest path.
+func TestMultipleGoals(t *testing.T) {
+	search := func(stopAfter int) *SbpBfsFinder {
+		f := newFinder()
+		f.AddGoal("bottom left", games.And(games.PieceAt(2, 3, 0), games.PieceAt(2, 4, 1)))
+		f.AddGoal("two bottom rows", games.Region(2, 3, 0, 4, 3))
+		f.AddGoal("out of the board", games.PieceAt(2, 9, 9))
+		f.StopAfterGoals(stopAfter)
+		f.SolvePuzzle(newGame(pennant))
+		return f
+	}
+
+	f := search(0)
+	results := f.GoalResults()
+	if len(results) != 3 || results[0].Name != "bottom left" {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	if !results[0].Found || results[0].Length != 59 {
+		t.Errorf("Pennant goal: found %v, len %d", results[0].Found, results[0].Length)
+	}
+	if !results[1].Found || results[1].Length >= 59 || len(results[1].Solution) == 0 {
+		t.Errorf("Region goal: found %v, len %d", results[1].Found, results[1].Length)
+	}
+	if results[2].Found {
+		t.Errorf("Unreachable goal found")
+	}
+	if _, length, _ := f.GetResult(); length != results[1].Length {
+		t.Errorf("GetResult should give the closest goal: %d", length)
+	}
+
+	// The closest goal is reached first
+	f = search(1)
+	results = f.GoalResults()
+	if f.EndStatus() != "Goals reached." || !results[1].Found || results[0].Found {
+		t.Errorf("Unexpected early stop: %s, %+v", f.EndStatus(), results)
+	}
+}
+
+// Mirror states share one entry: same solutions, about half the states.
+func TestSymmetry(t *testing.T) {
+	aneRouge := grids.Matrix2d{
+		[]int{2, 1, 1, 3},
+		[]int{2, 1, 1, 3},
+		[]int{4, 5, 5, 6},
+		[]int{4, 8, 9, 6},
+		[]int{7, 0, 0, 10},
+	}
+
+	solve := func(goal defs.Goal, symmetry bool) *SbpBfsFinder {
+		f := newFinder()
+		f.SetSymmetry(symmetry)
+		f.DetectGoal(goal)
+		f.SolvePuzzle(newGame(aneRouge))
+		return f
+	}
+
+	// The big piece at the bottom center, and at the bottom left corner: the second solution is
+	// found on the mirror state, and mapped back
+	goals := []defs.Goal{
+		games.And(games.PieceAt(1, 3, 1), games.PieceAt(1, 4, 2)),
+		games.And(games.PieceAt(1, 3, 0), games.PieceAt(1, 4, 1)),
+	}
+	for i, goal := range goals {
+		f, fs := solve(goal, false), solve(goal, true)
+		_, length, _ := f.GetResult()
+		found, symLength, _ := fs.GetResult()
+		if !found || symLength != length || 2*fs.StatesCount() > f.StatesCount()+100 {
+			t.Errorf("Goal %d: len %d (%d states), with symmetry %d (%d states)", i, length, f.StatesCount(), symLength, fs.StatesCount())
+		}
+		if !goal.Reached(replay(newGame(aneRouge), fs.Solution())) {
+			t.Errorf("Goal %d: the solution does not reach the goal", i)
+		}
+	}
+
+	var f MoveExtremalFinder
+	f.SilentMode(true)
+	f.SetLimits(300, 0)
+	f.SetSymmetry(true)
+	f.FindExtremals(newGame(aneRouge))
+	if f.Distance() != 124 || f.StatesCount() != 13011 {
+		t.Errorf("Ane Rouge up to symmetry: distance %d, %d states", f.Distance(), f.StatesCount())
+	}
+
+	end := replay(newGame(aneRouge), f.ExamplePath())
+	if s := f.Extremals()[0].(*games.SBPState); !s.Equal(end) && !s.EqualUnder(end, grids.MIRROR_LR) {
+		t.Errorf("Example path does not reach the extremal state or its mirror")
+	}
+}
+
+// Sparse boards give the same searches as dense ones.
+func TestSparseBoard(t *testing.T) {
+	goal := games.Template(&grids.Matrix2d{
+		[]int{0, 0, 0, 0},
+		[]int{0, 0, 0, 0},
+		[]int{0, 0, 0, 0},
+		[]int{2, 2, 0, 0},
+		[]int{2, 2, 0, 0},
+	})
+
+	var lengths, states, distances []int
+	for _, kind := range []games.BoardKind{games.DENSE_BOARD, games.SPARSE_BOARD} {
+		f := newFinder()
+		f.DetectGoal(goal)
+		f.SolvePuzzle(newLatticeGame(pennant, grids.SQUARE_LATTICE, kind))
+		found, length, _ := f.GetResult()
+		if !found {
+			t.Fatalf("Pennant not solved with board kind %d", kind)
+		}
+		if !goal.Reached(replay(newLatticeGame(pennant, grids.SQUARE_LATTICE, kind), f.Solution())) {
+			t.Errorf("Board kind %d: the solution does not reach the goal", kind)
+		}
+
+		var e MoveExtremalFinder
+		e.SilentMode(true)
+		e.SetLimits(300, 0)
+		e.FindExtremals(newLatticeGame(pennant, grids.SQUARE_LATTICE, kind))
+
+		lengths = append(lengths, length)
+		states = append(states, e.StatesCount())
+		distances = append(distances, e.Distance())
+	}
+	if lengths[0] != lengths[1] || states[0] != states[1] || distances[0] != distances[1] {
+		t.Errorf("Dense and sparse differ: lengths %v, states %v, distances %v", lengths, states, distances)
+	}
+
+	// A big board with lots of free space: the small piece may be on any free cell
+	board := make(grids.Matrix2d, 10)
+	for r := range board {
+		board[r] = make([]int, 10)
+	}
+	board[0][0] = 1
+	board[4][4], board[4][5], board[5][4], board[5][5] = 2, 2, 2, 2
+
+	var e MoveExtremalFinder
+	e.SilentMode(true)
+	e.SetLimits(300, 0)
+	e.FindExtremals(newLatticeGame(board, grids.SQUARE_LATTICE, games.SPARSE_BOARD))
+	if e.StatesCount() != 81*96 {
+		t.Errorf("Sparse board: %d states, should be %d", e.StatesCount(), 81*96)
+	}
+}
+
+// Hex and triangle puzzles are solved by the same finders, on every kind of board.
+func TestLattices(t *testing.T) {
+	cases := []struct {
+		lattice grids.Lattice
+		board   grids.Matrix2d
+		goal    defs.Goal
+	}{
+		{grids.HEX_LATTICE, grids.Matrix2d{
+			[]int{1, 1, 0, 2},
+			[]int{1, 0, 3, 2},
+			[]int{4, 4, 0, 5},
+		}, games.PieceAt(4, 1, 0)},
+		{grids.TRIANGLE_LATTICE, grids.Matrix2d{
+			[]int{1, 1, 0, 2, 2, 0, 0},
+			[]int{0, 3, 3, 0, 4, 0, 0},
+			[]int{0, 0, 0, 0, 0, 5, 5},
+		}, games.PieceAt(4, 0, 1)},
+	}
+
+	for _, c := range cases {
+		var states []int
+		for _, kind := range []games.BoardKind{games.DENSE_BOARD, games.SPARSE_BOARD, games.BIT_BOARD} {
+			f := newFinder()
+			f.DetectGoal(c.goal)
+			f.SolvePuzzle(newLatticeGame(c.board, c.lattice, kind))
+			if found, _, _ := f.GetResult(); !found {
+				t.Fatalf("%v lattice, board kind %d: not solved", c.lattice, kind)
+			}
+			if !c.goal.Reached(replay(newLatticeGame(c.board, c.lattice, kind), f.Solution())) {
+				t.Errorf("%v lattice, board kind %d: the solution does not reach the goal", c.lattice, kind)
+			}
+
+			var e MoveExtremalFinder
+			e.SilentMode(true)
+			e.SetLimits(300, 0)
+			e.FindExtremals(newLatticeGame(c.board, c.lattice, kind))
+			states = append(states, e.StatesCount())
+		}
+
+		var a Analyzer
+		a.SilentMode(true)
+		a.ExplorePlayable(newLatticeGame(c.board, c.lattice, games.AUTO_BOARD))
+		if states[0] != a.StatesCount() || states[1] != states[0] || states[2] != states[0] {
+			t.Errorf("%v lattice: %v states, the analyzer found %d", c.lattice, states, a.StatesCount())
+		}
+	}
+}
+
+// 3D puzzles are solved by the same finders. On a 2x2x2 shifter, half of the arrangements of the cubes
+// are reachable.
+func Test3D(t *testing.T) {
+	newGame3 := func(alike bool) *games.SB3Game {
+		var g games.SB3Game
+		g.Define(&grids.Matrix3d{
+			{
+				{1, 2},
+				{3, 4},
+			},
+			{
+				{5, 6},
+				{7, 0},
+			},
+		})
+		if alike {
+			g.AutoAlikePieces()
+			g.SetNotAlikePiece(1)
+		}
+		g.Build()
+		return &g
+	}
+	goal := games.PieceAt3(1, 1, 1, 1)
+
+	for _, alike := range []bool{false, true} {
+		f := newFinder()
+		f.DetectGoal(goal)
+		f.SolvePuzzle(newGame3(alike))
+		found, length, _ := f.GetResult()
+		if !found || length != 9 {
+			t.Fatalf("Alike %v: found %v, length %d, should be 9", alike, found, length)
+		}
+		if !goal.Reached(replay(newGame3(alike), f.Solution())) {
+			t.Errorf("Alike %v: the solution does not reach the goal", alike)
+		}
+
+		e := newFinder()
+		e.SetHardOptimal(false)
+		e.FindExtremals(newGame3(alike))
+
+		var a Analyzer
+		a.SilentMode(true)
+		a.ExplorePlayable(newGame3(alike))
+
+		expected := 8 * 7 * 6 * 5 * 4 * 3 * 2 / 2
+		if alike {
+			expected = 8 * 7
+		}
+		if e.StatesCount() != expected || a.StatesCount() != expected {
+			t.Errorf("Alike %v: %d and %d states, should be %d", alike, e.StatesCount(), a.StatesCount(), expected)
+		}
+
+		// In move metric: with one hole, every step is a move
+		var m MoveExtremalFinder
+		m.SilentMode(true)
+		m.DetectGoal(goal)
+		m.SolvePuzzle(newGame3(alike))
+		if found, length, _ := m.GetResult(); !found || length != 9 {
+			t.Errorf("Alike %v, move metric: found %v, length %d, should be 9", alike, found, length)
+		}
+		if !goal.Reached(replay(newGame3(alike), m.Solution())) {
+			t.Errorf("Alike %v, move metric: the solution does not reach the goal", alike)
+		}
+		m.FindExtremals(newGame3(alike))
+		if m.StatesCount() != expected || !replay(newGame3(alike), m.ExamplePath()).Equal(m.Extremals()[0]) {
+			t.Errorf("Alike %v, move metric: %d states, should be %d", alike, m.StatesCount(), expected)
+		}
+	}
+
+	// With one hole, a cube never moves twice in a row: moves are single steps
+	f := newFinder()
+	f.SetHardOptimal(false)
+	f.DetectGoal(goal)
+	f.SolvePuzzle(newGame3(true))
+	if _, length, _ := f.GetResult(); length != 9 || len(f.Solution()) != 9 {
+		t.Errorf("Length %d in %d steps, should be 9", length, len(f.Solution()))
+	}
+}
@@ -0,0 +1,69 @@
+package finder
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/search"
+
+// Hash of the state, the same for all its images by the symmetries
+func stateHash(s defs.SeqGameState, syms []grids.Symmetry) int {
+	if len(syms) == 0 {
+		return s.ToHash()
+	}
+	return s.(*games.SBPState).CanonicalHash(syms)
+}
+
+// The symmetry taking s to st, if any
+func symmetryBetween(st defs.SeqGameState, s defs.SeqGameState, syms []grids.Symmetry) (grids.Symmetry, bool) {
+	for _, sym := range syms {
+		if st.(*games.SBPState).EqualUnder(s, sym) {
+			return sym, true
+		}
+	}
+	return grids.IDENTITY, false
+}
+
+// Symmetries of the puzzle, if enabled
+func (f *SbpBfsFinder) initSymmetries() {
+	f.symmetries_ = nil
+	if g, ok := f.game_.(*games.SBGame); ok && f.symmetry_ {
+		f.symmetries_ = g.Symmetries()
+	}
+}
+
+// The state of child is the image of the visited state st by the symmetry: a shorter path to it gives a
+// shorter path to st. Equivalencies are not added, since the movements of the child state do not apply to st.
+func (f *SbpBfsFinder) revisitSymmetric(st defs.SeqGameState, child *search.Node[defs.SeqGameState, defs.Command], sym grids.Symmetry) {
+	s := child.State()
+	if s.CollapsedPathLen() >= st.CollapsedPathLen() {
+		return
+	}
+
+	st.SetMovChain(f.imagePath(s, sym), nil)
+	if st.IsObjective() {
+		f.checkGoals(st)
+	}
+	if f.hardOptimals_ {
+		f.requeue(child)
+	}
+}
+
+// Image of the state by the symmetry, with the image of its path
+func (f *SbpBfsFinder) image(s defs.SeqGameState, sym grids.Symmetry) defs.SeqGameState {
+	img := s.(*games.SBPState).Transformed(sym)
+
+	// Replaying the path sets the ids of alike pieces
+	img.SetMovChain(f.imagePath(s, sym), &f.initState_)
+	return img
+}
+
+func (f *SbpBfsFinder) imagePath(s defs.SeqGameState, sym grids.Symmetry) *defs.MovChain {
+	start := f.initState_.(*games.SBPState).Grid()
+	return defs.NewMovChain(games.TransformPath(start, s.PathChain(), sym))
+}
+
+func (f *SbpBfsFinder) resumeSymmetries() {
+	if len(f.symmetries_) > 0 {
+		f.outDbg2_.Printf("\n Symmetries: %v", f.symmetries_)
+	}
+}
@@ -0,0 +1,123 @@
+package formats
+
+import "fmt"
+import "strings"
+import "unicode"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// ASCII boards: one character per cell, one row per line.
+const (
+	ASCII_EMPTY = '.'
+	ASCII_WALL  = '#'
+)
+
+// Imports a sliding blocks puzzle drawn with characters: '.' is an empty cell, '#' a wall and any
+// other character a piece (all cells with that character). Whitespace is ignored, so cells can be
+// separated by spaces, and blank lines are skipped.
+//
+// Piece ids are given in reading order (top to bottom, left to right) starting at 1. All walls are
+// one fixed piece, with the last id. The goal board shows only the pieces that must reach a place;
+// the rest of the cells can be '.' or '#'.
+func ParseASCII(start string, goal string) (*puzzles.Puzzle, error) {
+	return parseASCIILines(splitLines(start), splitLines(goal))
+}
+
+func parseASCIILines(startLines []string, goalLines []string) (*puzzles.Puzzle, error) {
+	startRows := asciiRows(startLines)
+	goalRows := asciiRows(goalLines)
+
+	// Assign ids in reading order
+	ids := make(map[rune]int)
+	nextId := 1
+	hasWalls := false
+	for _, row := range startRows {
+		for _, c := range row {
+			switch c {
+			case ASCII_EMPTY:
+			case ASCII_WALL:
+				hasWalls = true
+			default:
+				if _, ok := ids[c]; !ok {
+					ids[c] = nextId
+					nextId++
+				}
+			}
+		}
+	}
+	wallId := nextId
+
+	start, err := asciiMatrix(startRows, func(c rune) (int, error) {
+		switch c {
+		case ASCII_EMPTY:
+			return 0, nil
+		case ASCII_WALL:
+			return wallId, nil
+		}
+		return ids[c], nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("start board: %v", err)
+	}
+
+	goalMatrix, err := asciiMatrix(goalRows, func(c rune) (int, error) {
+		if c == ASCII_EMPTY || c == ASCII_WALL {
+			return 0, nil
+		}
+		id, ok := ids[c]
+		if !ok {
+			return 0, fmt.Errorf("piece '%c' is not on the start board", c)
+		}
+		return id, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("goal board: %v", err)
+	}
+
+	var fixed []int
+	if hasWalls {
+		fixed = append(fixed, wallId)
+	}
+	return NewSBPPuzzle(start, goalMatrix, fixed)
+}
+
+// Board rows without whitespace, skipping blank lines
+func asciiRows(lines []string) [][]rune {
+	var rows [][]rune
+	for _, line := range lines {
+		var row []rune
+		for _, c := range line {
+			if !unicode.IsSpace(c) {
+				row = append(row, c)
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func asciiMatrix(rows [][]rune, cellValue func(c rune) (int, error)) (grids.Matrix2d, error) {
+	var m grids.Matrix2d
+	for r, row := range rows {
+		if len(row) != len(rows[0]) {
+			return nil, fmt.Errorf("row %d has %d cells, should have %d", r+1, len(row), len(rows[0]))
+		}
+		values := make([]int, len(row))
+		for c, x := range row {
+			v, err := cellValue(x)
+			if err != nil {
+				return nil, err
+			}
+			values[c] = v
+		}
+		m = append(m, values)
+	}
+	return m, nil
+}
+
+func splitLines(s string) []string {
+	return strings.Split(strings.Replace(s, "\r\n", "\n", -1), "\n")
+}
@@ -0,0 +1,194 @@
+package formats
+
+import "bufio"
+import "fmt"
+import "io"
+import "strconv"
+import "strings"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Board formats of a collection
+const (
+	ASCII_FORMAT     = "ascii"
+	NUMERIC_FORMAT   = "numeric"
+	SBPSEARCH_FORMAT = "sbpsearch"
+)
+
+// Reads a collection of sliding blocks puzzles. Each puzzle is a block of 'key: value' lines followed
+// by its start and goal boards, and ends at a blank line:
+//
+//	name: Pennant
+//	source: http://www.puzzlopia.com/puzzles/pennant/play
+//	optimal: 59
+//	start:
+//	AABB
+//	AACC
+//	ED..
+//	FGHH
+//	FGII
+//	goal:
+//	....
+//	....
+//	....
+//	AA..
+//	AA..
+//
+// Keys are name (required), author, source, optimal, format ('ascii', the default, 'numeric' or
+// 'sbpsearch'), max-depth and max-states. Lines starting with ';' are comments. SBPSearch levels (see
+// ParseSBPSearch) go in a 'level:' section instead of the start and goal boards.
+func ReadCollection(r io.Reader) ([]*puzzles.Puzzle, error) {
+	var res []*puzzles.Puzzle
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	var cur *collectionEntry
+	section := ""
+
+	finish := func() error {
+		if cur == nil {
+			return nil
+		}
+		p, err := cur.build()
+		if err != nil {
+			return fmt.Errorf("puzzle at line %d: %v", cur.line_, err)
+		}
+		res = append(res, p)
+		cur = nil
+		section = ""
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+		if trimmed == "" {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if cur == nil {
+			cur = &collectionEntry{line_: lineNum, format_: ASCII_FORMAT}
+		}
+
+		switch {
+		case trimmed == "start:":
+			section = "start"
+		case trimmed == "goal:":
+			section = "goal"
+		case trimmed == "level:":
+			section = "level"
+		case section == "start":
+			cur.start_ = append(cur.start_, line)
+		case section == "goal":
+			cur.goal_ = append(cur.goal_, line)
+		case section == "level":
+			cur.level_ = append(cur.level_, line)
+		default:
+			if err := cur.setKey(trimmed); err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNum, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// A puzzle being read
+type collectionEntry struct {
+	line_ int
+
+	name_      string
+	author_    string
+	source_    string
+	format_    string
+	optimal_   int
+	maxDepth_  int
+	maxStates_ int
+
+	start_ []string
+	goal_  []string
+	level_ []string
+}
+
+func (e *collectionEntry) setKey(line string) (err error) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return fmt.Errorf("expected 'key: value', found %q", line)
+	}
+	key := strings.TrimSpace(line[:idx])
+	value := strings.TrimSpace(line[idx+1:])
+
+	switch key {
+	case "name":
+		e.name_ = value
+	case "author":
+		e.author_ = value
+	case "source":
+		e.source_ = value
+	case "format":
+		if value != ASCII_FORMAT && value != NUMERIC_FORMAT && value != SBPSEARCH_FORMAT {
+			return fmt.Errorf("unknown format %q", value)
+		}
+		e.format_ = value
+	case "optimal":
+		e.optimal_, err = strconv.Atoi(value)
+	case "max-depth":
+		e.maxDepth_, err = strconv.Atoi(value)
+	case "max-states":
+		e.maxStates_, err = strconv.Atoi(value)
+	default:
+		return fmt.Errorf("unknown key %q", key)
+	}
+	return err
+}
+
+func (e *collectionEntry) build() (p *puzzles.Puzzle, err error) {
+	if e.name_ == "" {
+		return nil, fmt.Errorf("missing name")
+	}
+	if e.format_ == SBPSEARCH_FORMAT {
+		if len(e.level_) == 0 {
+			return nil, fmt.Errorf("%s: missing level", e.name_)
+		}
+	} else if len(e.start_) == 0 || len(e.goal_) == 0 {
+		return nil, fmt.Errorf("%s: missing start or goal board", e.name_)
+	}
+
+	switch e.format_ {
+	case NUMERIC_FORMAT:
+		p, err = parseNumericLines(e.start_, e.goal_)
+	case SBPSEARCH_FORMAT:
+		p, err = parseSBPSearchLines(e.level_)
+	default:
+		p, err = parseASCIILines(e.start_, e.goal_)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", e.name_, err)
+	}
+
+	p.Name = e.name_
+	p.Author = e.author_
+	p.Source = e.source_
+	p.Optimal = e.optimal_
+	if e.maxDepth_ > 0 {
+		p.MaxDepth = e.maxDepth_
+	}
+	if e.maxStates_ > 0 {
+		p.MaxStates = e.maxStates_
+	}
+	return p, nil
+}
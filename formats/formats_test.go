@@ -0,0 +1,151 @@
+package formats
+
+import "os"
+import "reflect"
+import "strings"
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/checks"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+func readTestCollection(t *testing.T) []*puzzles.Puzzle {
+	f, err := os.Open("testdata/collection.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	res, err := ReadCollection(f)
+	if err != nil {
+		t.Fatalf("ReadCollection failed: %v", err)
+	}
+	return res
+}
+
+func TestReadCollection(t *testing.T) {
+	res := readTestCollection(t)
+	if len(res) != 3 {
+		t.Fatalf("Should read 3 puzzles, read %d", len(res))
+	}
+
+	ascii, numeric := res[0], res[1]
+	if ascii.Name != "PennantASCII" || ascii.Optimal != 59 || ascii.Source == "" {
+		t.Errorf("Wrong metadata: %s %d %s", ascii.Name, ascii.Optimal, ascii.Source)
+	}
+	if numeric.MaxStates != 500000 || numeric.MaxDepth != DEFAULT_MAX_DEPTH {
+		t.Errorf("Wrong limits: %d %d", numeric.MaxDepth, numeric.MaxStates)
+	}
+
+	// Ids in reading order
+	if ascii.Start.At(0, 0) != 1 || ascii.Start.At(4, 3) != 9 || ascii.Goal.At(4, 1) != 1 {
+		t.Errorf("Wrong ids: %v / %v", ascii.Start, ascii.Goal)
+	}
+	if !reflect.DeepEqual(numeric.Start, puzzles.Lookup("Pennant").Start) {
+		t.Errorf("Numeric board differs from the catalog: %v", numeric.Start)
+	}
+
+	// The big piece is the goal piece; the four small squares and the four 2x1 pieces are alike
+	if !reflect.DeepEqual(ascii.NotAlike, []int{1}) {
+		t.Errorf("Goal pieces should be not alike: %v", ascii.NotAlike)
+	}
+	if !reflect.DeepEqual(ascii.Alike, [][]int{[]int{2, 3, 8, 9}, []int{4, 5}, []int{6, 7}}) {
+		t.Errorf("Wrong alike groups: %v", ascii.Alike)
+	}
+}
+
+func TestSolveImported(t *testing.T) {
+	res := readTestCollection(t)
+
+	for _, p := range res[:2] {
+		r := checks.RunCase(p, checks.BenchmarkLimits{}, true)
+		if !r.Pass() {
+			t.Errorf("%s: found %v, length %d, should be %d", p.Name, r.Found, r.Length, p.Optimal)
+		}
+	}
+}
+
+func TestWalls(t *testing.T) {
+	walled := readTestCollection(t)[2]
+	if !reflect.DeepEqual(walled.Fixed, []int{4}) || walled.Start.At(0, 1) != 4 {
+		t.Fatalf("Wall should be fixed piece 4: %v %v", walled.Fixed, walled.Start)
+	}
+
+	g := walled.NewSBGame()
+	for _, m := range g.ValidMovementsBFS(nil) {
+		if m.PieceId() == 4 {
+			t.Errorf("Wall should not move: %v", m)
+		}
+	}
+
+	r := checks.RunCase(walled, checks.BenchmarkLimits{}, true)
+	if !r.Found {
+		t.Errorf("Walled not solved: %s", r.EndStatus)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := []struct{ start, goal string }{
+		{"AB\nC", ".."},          // ragged rows
+		{"AB\nCD", "..\n..\n.."}, // sizes differ
+		{"AB\nCD", "X.\n.."},     // unknown piece
+		{"AA\nBC", ".A\n.A"},     // shape differs
+	}
+	for _, c := range cases {
+		if _, err := ParseASCII(c.start, c.goal); err == nil {
+			t.Errorf("ParseASCII(%q, %q) should fail", c.start, c.goal)
+		}
+	}
+
+	if _, err := ParseNumeric("1 x\n0 0", "0 0\n0 0"); err == nil {
+		t.Error("ParseNumeric with an invalid cell should fail")
+	}
+}
+
+func TestNumericWalls(t *testing.T) {
+	p, err := ParseNumeric("1 -1 0\n2 0 0", "0 -1 1\n0 0 0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(p.Start, grids.Matrix2d{[]int{1, 3, 0}, []int{2, 0, 0}}) || !reflect.DeepEqual(p.Fixed, []int{3}) {
+		t.Errorf("Wrong walls: %v %v", p.Start, p.Fixed)
+	}
+
+	var g *games.SBGame = p.NewSBGame()
+	if g == nil {
+		t.Error("Game not built")
+	}
+}
+
+func TestSBPSearch(t *testing.T) {
+	level := "4 5\nAABB\nAACC\nDE..\nFGHH\nFGII\n....\n....\n....\nAA..\nAA..\n"
+	p, err := ParseSBPSearch(level)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ascii := readTestCollection(t)[0]
+	if !reflect.DeepEqual(p.Start, ascii.Start) || !reflect.DeepEqual(p.Goal, ascii.Goal) || !reflect.DeepEqual(p.Alike, ascii.Alike) {
+		t.Errorf("SBPSearch level differs from the ASCII boards: %v / %v", p.Start, p.Goal)
+	}
+
+	// In a collection
+	res, err := ReadCollection(strings.NewReader("name: PennantSBPSearch\nformat: sbpsearch\noptimal: 59\nlevel:\n" + level))
+	if err != nil {
+		t.Fatalf("ReadCollection failed: %v", err)
+	}
+	if len(res) != 1 || res[0].Name != "PennantSBPSearch" || !reflect.DeepEqual(res[0].Start, ascii.Start) {
+		t.Errorf("Wrong SBPSearch puzzle in the collection: %v", res)
+	}
+
+	for _, bad := range []string{
+		"",                // no size
+		"4\nAABB",         // size without height
+		"2 1\nAB\n..\n..", // too many rows
+		"2 1\nABC\n...",   // wrong width
+	} {
+		if _, err := ParseSBPSearch(bad); err == nil {
+			t.Errorf("ParseSBPSearch(%q) should fail", bad)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package formats
+
+import "fmt"
+import "strconv"
+import "strings"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Imports a sliding blocks puzzle given as integer matrices, the layout used by this solver and
+// other matrix based solvers: one row per line, cells separated by spaces or commas, 0 for empty
+// cells and the piece id for piece cells. Negative cells are walls: all of them are one fixed
+// piece, with the max id + 1. Brackets are ignored, so rows like '[2, 2, 1, 1]' or '[]int{2, 2, 1, 1},'
+// can be pasted.
+//
+// The goal matrix shows only the pieces that must reach a place, with 0 (or walls) elsewhere.
+func ParseNumeric(start string, goal string) (*puzzles.Puzzle, error) {
+	return parseNumericLines(splitLines(start), splitLines(goal))
+}
+
+func parseNumericLines(startLines []string, goalLines []string) (*puzzles.Puzzle, error) {
+	start, err := numericMatrix(startLines)
+	if err != nil {
+		return nil, fmt.Errorf("start board: %v", err)
+	}
+	goal, err := numericMatrix(goalLines)
+	if err != nil {
+		return nil, fmt.Errorf("goal board: %v", err)
+	}
+
+	// Walls
+	var fixed []int
+	wallId := start.Max() + 1
+	for r := 0; r < start.Rows(); r++ {
+		for c := 0; c < start.Cols(); c++ {
+			if start.At(r, c) < 0 {
+				start.SetAt(r, c, wallId)
+				fixed = []int{wallId}
+			}
+		}
+	}
+	for r := 0; r < goal.Rows(); r++ {
+		for c := 0; c < goal.Cols(); c++ {
+			if goal.At(r, c) < 0 {
+				goal.SetAt(r, c, 0)
+			}
+		}
+	}
+
+	return NewSBPPuzzle(start, goal, fixed)
+}
+
+func numericMatrix(lines []string) (grids.Matrix2d, error) {
+	var m grids.Matrix2d
+	for _, line := range lines {
+		line = strings.Replace(line, "[]int", "", -1)
+		fields := strings.FieldsFunc(line, func(c rune) bool {
+			return c == ' ' || c == '\t' || c == ',' || c == '[' || c == ']' || c == '{' || c == '}'
+		})
+		if len(fields) == 0 {
+			continue
+		}
+
+		row := make([]int, len(fields))
+		for i, f := range fields {
+			v, err := strconv.Atoi(f)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid cell %q", len(m)+1, f)
+			}
+			row[i] = v
+		}
+		if len(m) > 0 && len(row) != len(m[0]) {
+			return nil, fmt.Errorf("row %d has %d cells, should have %d", len(m)+1, len(row), len(m[0]))
+		}
+		m = append(m, row)
+	}
+	return m, nil
+}
@@ -0,0 +1,96 @@
+package formats
+
+import "fmt"
+import "sort"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Finder limits of imported puzzles, unless the collection sets them
+const (
+	DEFAULT_MAX_DEPTH  = 300
+	DEFAULT_MAX_STATES = 1999999
+)
+
+// Builds a sliding blocks puzzle from start and goal matrices, checking the goal pieces fit.
+// Goal pieces are kept distinct; alike pieces are detected among the rest (see grids.DetectAlikePieces).
+func NewSBPPuzzle(start grids.Matrix2d, goal grids.Matrix2d, fixed []int) (*puzzles.Puzzle, error) {
+	if start.Rows() == 0 || start.Cols() == 0 {
+		return nil, fmt.Errorf("empty start board")
+	}
+	if start.Rows() != goal.Rows() || start.Cols() != goal.Cols() {
+		return nil, fmt.Errorf("goal board is %dx%d, start board is %dx%d", goal.Rows(), goal.Cols(), start.Rows(), start.Cols())
+	}
+
+	var startPieces, goalPieces []*grids.GridPiece2
+	start.GeneratePieces(&startPieces, nil)
+	goal.GeneratePieces(&goalPieces, nil)
+
+	byId := make(map[int]*grids.GridPiece2)
+	for _, p := range startPieces {
+		byId[p.Id()] = p
+	}
+
+	var goalIds []int
+	for _, p := range goalPieces {
+		q := byId[p.Id()]
+		if q == nil {
+			return nil, fmt.Errorf("goal piece %d is not on the start board", p.Id())
+		}
+		if !p.Equivalent(q) {
+			return nil, fmt.Errorf("goal piece %d has not the same shape as on the start board", p.Id())
+		}
+		for _, id := range fixed {
+			if id == p.Id() {
+				return nil, fmt.Errorf("goal piece %d is a wall", id)
+			}
+		}
+		goalIds = append(goalIds, p.Id())
+	}
+	sort.Ints(goalIds)
+
+	return &puzzles.Puzzle{
+		Kind:  puzzles.SBP_PUZZLE,
+		Start: start,
+		Goal:  goal,
+
+		Alike:    detectAlike(&start, startPieces, append(append([]int{}, goalIds...), fixed...)),
+		NotAlike: goalIds,
+		Fixed:    fixed,
+
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  DEFAULT_MAX_DEPTH,
+		MaxStates: DEFAULT_MAX_STATES,
+	}, nil
+}
+
+// Groups of pieces with the same shape, leaving out the excluded ones. Ids are in board order.
+func detectAlike(m *grids.Matrix2d, pieces []*grids.GridPiece2, exclude []int) [][]int {
+	grids.DetectAlikePieces(pieces, m.Max()+1)
+
+	excluded := make(map[int]bool)
+	for _, id := range exclude {
+		excluded[id] = true
+	}
+
+	var values []int
+	groups := make(map[int][]int)
+	for _, p := range pieces {
+		if excluded[p.Id()] {
+			continue
+		}
+		if _, ok := groups[p.Value()]; !ok {
+			values = append(values, p.Value())
+		}
+		groups[p.Value()] = append(groups[p.Value()], p.Id())
+	}
+
+	var alike [][]int
+	for _, v := range values {
+		if len(groups[v]) > 1 {
+			alike = append(alike, groups[v])
+		}
+	}
+	return alike
+}
@@ -0,0 +1,47 @@
+package formats
+
+import "fmt"
+import "strings"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Imports an SBPSearch level: a 'width height' line followed by the start board and the goal board,
+// height rows each, with no separator between them. Cells are characters as in ParseASCII ('.' empty,
+// '#' wall, any other character a piece), whitespace is ignored and blank lines are skipped.
+func ParseSBPSearch(level string) (*puzzles.Puzzle, error) {
+	return parseSBPSearchLines(splitLines(level))
+}
+
+func parseSBPSearchLines(lines []string) (*puzzles.Puzzle, error) {
+
+	// Size line: the first one that is not blank
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("empty level")
+	}
+	var width, height int
+	if n, err := fmt.Sscanf(lines[0], "%d %d", &width, &height); n != 2 || err != nil || width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("expected 'width height', found %q", lines[0])
+	}
+
+	rows := asciiRows(lines[1:])
+	if len(rows) != 2*height {
+		return nil, fmt.Errorf("%d board rows, should be %d (start and goal)", len(rows), 2*height)
+	}
+	for r, row := range rows {
+		if len(row) != width {
+			return nil, fmt.Errorf("row %d has %d cells, should have %d", r+1, len(row), width)
+		}
+	}
+
+	var start, goal []string
+	for _, row := range rows[:height] {
+		start = append(start, string(row))
+	}
+	for _, row := range rows[height:] {
+		goal = append(goal, string(row))
+	}
+	return parseASCIILines(start, goal)
+}
@@ -10,6 +10,16 @@ type EngelCommand struct {
 	rotation_ int
 }
 
+// Returns the command turning the wheel (0 left, 1 right) by 'rotation' sixths of a turn, clockwise.
+// Rotation is normalized to 0..5.
+func NewEngelCommand(wheelId int, rotation int) *EngelCommand {
+	rotation = rotation % 6
+	if rotation < 0 {
+		rotation += 6
+	}
+	return &EngelCommand{wheelId, rotation}
+}
+
 // Implements command interface
 func (c *EngelCommand) PieceId() int {
 	return c.wheelId_
@@ -51,5 +61,5 @@ func (c *EngelCommand) Print() {
 	if c.wheelId_ == 1 {
 		wheelName = 'R'
 	}
-	fmt.Printf("[%s %d]", wheelName, c.rotation_)
+	fmt.Printf("[%c %d]", wheelName, c.rotation_)
 }
@@ -19,6 +19,9 @@ func (g *EngelGame) Define(leftWheelPieces [12]int, rightWheelPieces [12]int) {
 	g.state_.Init(leftWheelPieces, rightWheelPieces)
 	g.state_.SetInitial()
 
+	g.pieceToValue_ = defs.NewPieceToValue()
+	g.state_.SetPieceToValue(g.pieceToValue_)
+
 	g.pieces_ = leftWheelPieces[:]
 	g.pieces_ = append(g.pieces_, rightWheelPieces[:]...)
 }
@@ -28,11 +31,19 @@ func (g *EngelGame) DefineIntersectionPositions(leftPositions [3]int, rightPosit
 	g.state_.DefineIntersectionPositions(leftPositions, rightPositions)
 }
 
+// Indexs of common piece positions for the wheels (see DefineIntersectionPositions).
+func (g *EngelGame) IntersectionPositions() (leftPositions [3]int, rightPositions [3]int) {
+	return g.state_.wheelLeft_.intersectionPositions_, g.state_.wheelRight_.intersectionPositions_
+}
+
 // Identifies groups of pieces as being interchangeable. Creates a new identifier for each group.
 func (g *EngelGame) SetAlike(pieceIdGroups [][]int) {
 
-	// Assign the map
-	g.pieceToValue_ = defs.GetPieceToValueMap()
+	// The map is shared with all the game states: edit it in place
+	if g.pieceToValue_ == nil {
+		g.pieceToValue_ = defs.NewPieceToValue()
+		g.state_.SetPieceToValue(g.pieceToValue_)
+	}
 
 	maxId := 0
 	for _, id := range g.pieces_ {
@@ -0,0 +1,98 @@
+package engel
+
+import "math/big"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/groups"
+
+// Permutation group view of the puzzle. Wheel positions are numbered as slots: left wheel positions
+// are slots 0..11, followed by the right wheel positions that are not shared with the left wheel.
+
+// Returns a new state (not the game one) with the given wheel piece ids, e.g. a target configuration.
+// Pieces at intersection positions must be the same in both wheels.
+func (g *EngelGame) NewState(leftWheelPieces [12]int, rightWheelPieces [12]int) *EngelState {
+	s := &EngelState{}
+	s.Init(leftWheelPieces, rightWheelPieces)
+	s.DefineIntersectionPositions(g.state_.wheelLeft_.intersectionPositions_, g.state_.wheelRight_.intersectionPositions_)
+	s.SetPieceToValue(g.pieceToValue_)
+	return s
+}
+
+// Slot index of each right wheel position, and total number of slots.
+func (g *EngelGame) rightWheelSlots() (slots [12]int, numSlots int) {
+	left := g.state_.wheelLeft_.intersectionPositions_
+	right := g.state_.wheelRight_.intersectionPositions_
+
+	for i := range slots {
+		slots[i] = -1
+	}
+	for k := 0; k < 3; k++ {
+		slots[right[k]] = left[k]
+	}
+
+	numSlots = 12
+	for i := range slots {
+		if slots[i] < 0 {
+			slots[i] = numSlots
+			numSlots++
+		}
+	}
+	return
+}
+
+// Permutations of the slots performed by a 60 degree clockwise turn of each wheel.
+func (g *EngelGame) WheelPermutations() []groups.Perm {
+	rightSlots, numSlots := g.rightWheelSlots()
+
+	left := groups.Identity(numSlots)
+	right := groups.Identity(numSlots)
+	for i := 0; i < 12; i++ {
+		left[i] = (i + 2) % 12
+		right[rightSlots[i]] = rightSlots[(i+2)%12]
+	}
+	return []groups.Perm{left, right}
+}
+
+// Group generated by the wheel turns. Its order is the number of states with all pieces distinct.
+func (g *EngelGame) Group() *groups.PermGroup {
+	perms := g.WheelPermutations()
+	return groups.NewPermGroup(len(perms[0]), perms)
+}
+
+// Piece ids of a state, by slot.
+func (g *EngelGame) SlotPieces(s *EngelState) []int {
+	rightSlots, numSlots := g.rightWheelSlots()
+	ids := make([]int, numSlots)
+
+	for i := 0; i < 12; i++ {
+		ids[i] = s.wheelLeft_.At(i)
+		ids[rightSlots[i]] = s.wheelRight_.At(i)
+	}
+	return ids
+}
+
+// Piece value (see SetAlike) of a piece id
+func (g *EngelGame) PieceValue(id int) int {
+	if g.pieceToValue_ == nil {
+		return id
+	}
+	return g.pieceToValue_.At(id)
+}
+
+// Piece values (see SetAlike) of a state, by slot.
+func (g *EngelGame) Coloring(s *EngelState) []int {
+	coloring := g.SlotPieces(s)
+	for i, id := range coloring {
+		coloring[i] = g.PieceValue(id)
+	}
+	return coloring
+}
+
+// Number of distinct states reachable from the initial state, taking alike pieces into account.
+func (g *EngelGame) OrbitSize() *big.Int {
+	return g.Group().OrbitSize(g.Coloring(&g.state_))
+}
+
+// Returns true if the target state can be reached from the initial state.
+func (g *EngelGame) Solvable(target *EngelState) (bool, error) {
+	return g.Group().Solvable(g.Coloring(&g.state_), g.Coloring(target))
+}
@@ -0,0 +1,62 @@
+package engel
+
+import "testing"
+
+// Engel's SUN-MOON (see the puzzles catalog)
+func sunMoon(alike bool) *EngelGame {
+	var g EngelGame
+	g.Define([12]int{7, 6, 13, 14, 15, 16, 17, 18, 19, 20, 21, 8}, [12]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	g.DefineIntersectionPositions([3]int{11, 0, 1}, [3]int{7, 6, 5})
+	if alike {
+		g.SetAlike([][]int{
+			[]int{1, 3, 5, 7, 9, 11},
+			[]int{2, 4, 6, 8, 10, 12},
+			[]int{13, 15, 17, 19, 21},
+			[]int{14, 16, 18, 20},
+		})
+	}
+	return &g
+}
+
+func TestOrbitSize(t *testing.T) {
+
+	// The same number of states as a full exploration
+	if size := sunMoon(true).OrbitSize().Int64(); size != 97020 {
+		t.Errorf("SunMoon orbit: %d states, should be 97020", size)
+	}
+}
+
+func TestSolvable(t *testing.T) {
+
+	// Left and right wheel pieces with swapped positions
+	swapped := func(g *EngelGame, left int, right int) *EngelState {
+		l, r := g.state_.WheelPieces()
+		l[left], r[right] = r[right], l[left]
+		return g.NewState(l, r)
+	}
+
+	cases := []struct {
+		alike       bool
+		left, right int
+		result      bool
+	}{
+		// Turns are even permutations: two distinct pieces cannot be swapped
+		{false, 2, 0, false},
+
+		// Unless there are alike pieces to swap too
+		{true, 2, 0, true},
+
+		// Pieces never leave the slots of their shape
+		{true, 2, 1, false},
+	}
+
+	for _, c := range cases {
+		g := sunMoon(c.alike)
+		result, err := g.Solvable(swapped(g, c.left, c.right))
+		if err != nil {
+			t.Errorf("Alike %v, swap %d-%d: %v", c.alike, c.left, c.right, err)
+		} else if result != c.result {
+			t.Errorf("Alike %v, swap %d-%d: solvable %v, should be %v", c.alike, c.left, c.right, result, c.result)
+		}
+	}
+}
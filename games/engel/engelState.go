@@ -3,8 +3,6 @@ package engel
 import "fmt"
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
 
-var staticGameStateCount_ int = 0
-
 type EngelWheel struct {
 
 	// Wheels are defined with piece values, we don't need real piece pointers or piece real identifiers.
@@ -86,9 +84,16 @@ func (s *EngelState) Init(leftIds [12]int, rightIds [12]int) {
 	s.wheelRight_.SetPieces(rightIds)
 	s.prevMovWheels_ = [2]bool{false, false}
 	s.depth_ = 0
+}
+
+// Sets the map of piece values, owned by the game
+func (s *EngelState) SetPieceToValue(ptv *defs.PieceToValue) {
+	s.pieceToValue_ = ptv
+}
 
-	// Assign the map
-	s.pieceToValue_ = defs.GetPieceToValueMap()
+// Piece ids at each position of the left and right wheels
+func (s *EngelState) WheelPieces() (left [12]int, right [12]int) {
+	return s.wheelLeft_.Pieces(), s.wheelRight_.Pieces()
 }
 
 func (s *EngelState) DefineIntersectionPositions(leftPositions [3]int, rightPositions [3]int) {
@@ -114,8 +119,7 @@ func (g *EngelState) Uid() int {
 func (s *EngelState) Clone() defs.GameState {
 	var c EngelState
 
-	staticGameStateCount_++
-	c.uid_ = staticGameStateCount_
+	c.uid_ = defs.NextUid()
 	c.wheelLeft_.Copy(s.wheelLeft_)
 	c.wheelRight_.Copy(s.wheelRight_)
 	c.pieceToValue_ = s.pieceToValue_
@@ -128,7 +132,7 @@ func (s *EngelState) Clone() defs.GameState {
 
 func (s *EngelState) Equal(o defs.GameState) bool {
 	if s.pieceToValue_ == nil {
-		s.pieceToValue_ = defs.GetPieceToValueMap()
+		s.pieceToValue_ = defs.NewPieceToValue()
 	}
 	e := o.(*EngelState)
 	if s.wheelLeft_.Equal(e.wheelLeft_, s.pieceToValue_) && s.wheelRight_.Equal(e.wheelRight_, s.pieceToValue_) {
@@ -138,6 +142,9 @@ func (s *EngelState) Equal(o defs.GameState) bool {
 }
 
 func (s *EngelState) ToHash() int {
+	if s.pieceToValue_ == nil {
+		s.pieceToValue_ = defs.NewPieceToValue()
+	}
 	h := 1
 	for idx, id := range s.wheelLeft_.Pieces() {
 		h += idx * s.pieceToValue_.At(id)
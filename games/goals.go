@@ -0,0 +1,164 @@
+package games
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Built-in goals of sliding blocks puzzles. Pieces are compared by value, as states are: any piece alike
+// to the given one meets the condition (see SBGame.AlikePieces).
+
+// Non-zero cells of the template must hold the same pieces (see SBPState.EqualSub)
+func Template(m *grids.Matrix2d) defs.Goal {
+	g := &templateGoal{}
+	g.target_.Init(m)
+	return g
+}
+
+// The piece covers the cell
+func PieceAt(pieceId int, row int, col int) defs.Goal {
+	return &pieceAtGoal{pieceId, row, col}
+}
+
+// The piece lies inside the rectangle from (top, left) to (bottom, right), both included
+func Region(pieceId int, top int, left int, bottom int, right int) defs.Goal {
+	return &regionGoal{pieceId, top, left, bottom, right}
+}
+
+// Same as Template, for 3D puzzles (see SB3State.EqualSub)
+func Template3(m *grids.Matrix3d) defs.Goal {
+	g := &template3Goal{}
+	g.target_.Init(m)
+	return g
+}
+
+// Same as PieceAt, for 3D puzzles
+func PieceAt3(pieceId int, layer int, row int, col int) defs.Goal {
+	return &pieceAt3Goal{pieceId, grids.Coords3{layer, row, col}}
+}
+
+// All the goals are reached
+func And(goals ...defs.Goal) defs.Goal {
+	return &andGoal{goals}
+}
+
+// Any of the goals is reached
+func Or(goals ...defs.Goal) defs.Goal {
+	return &orGoal{goals}
+}
+
+// The goal is not reached
+func Not(goal defs.Goal) defs.Goal {
+	return &notGoal{goal}
+}
+
+type templateGoal struct {
+	target_ SBPState
+}
+
+func (g *templateGoal) Reached(s defs.SeqGameState) bool {
+	return s.EqualSub(&g.target_)
+}
+
+type pieceAtGoal struct {
+	pieceId_ int
+	row_     int
+	col_     int
+}
+
+func (g *pieceAtGoal) Reached(s defs.SeqGameState) bool {
+	st := s.(*SBPState)
+	if g.row_ < 0 || g.row_ >= st.grid.Rows() || g.col_ < 0 || g.col_ >= st.grid.Cols() {
+		return false
+	}
+	return st.value(st.grid.At(g.row_, g.col_)) == st.value(g.pieceId_)
+}
+
+type template3Goal struct {
+	target_ SB3State
+}
+
+func (g *template3Goal) Reached(s defs.SeqGameState) bool {
+	return s.EqualSub(&g.target_)
+}
+
+type pieceAt3Goal struct {
+	pieceId_ int
+	cell_    grids.Coords3
+}
+
+func (g *pieceAt3Goal) Reached(s defs.SeqGameState) bool {
+	st := s.(*SB3State)
+	l, r, c := g.cell_[0], g.cell_[1], g.cell_[2]
+	if l < 0 || l >= st.grid.Layers() || r < 0 || r >= st.grid.Rows() || c < 0 || c >= st.grid.Cols() {
+		return false
+	}
+	return st.value(st.grid.At(l, r, c)) == st.value(g.pieceId_)
+}
+
+type regionGoal struct {
+	pieceId_ int
+	top_     int
+	left_    int
+	bottom_  int
+	right_   int
+}
+
+func (g *regionGoal) Reached(s defs.SeqGameState) bool {
+	st := s.(*SBPState)
+	value := st.value(g.pieceId_)
+
+	// Cells of each piece with the value, in total and inside the region
+	cells := make(map[int]int)
+	inside := make(map[int]int)
+	for r, row := range *st.grid.Matrix() {
+		for c, id := range row {
+			if id == 0 || st.value(id) != value {
+				continue
+			}
+			cells[id]++
+			if r >= g.top_ && r <= g.bottom_ && c >= g.left_ && c <= g.right_ {
+				inside[id]++
+			}
+		}
+	}
+
+	for id, n := range cells {
+		if inside[id] == n {
+			return true
+		}
+	}
+	return false
+}
+
+type andGoal struct {
+	goals_ []defs.Goal
+}
+
+func (g *andGoal) Reached(s defs.SeqGameState) bool {
+	for _, x := range g.goals_ {
+		if !x.Reached(s) {
+			return false
+		}
+	}
+	return true
+}
+
+type orGoal struct {
+	goals_ []defs.Goal
+}
+
+func (g *orGoal) Reached(s defs.SeqGameState) bool {
+	for _, x := range g.goals_ {
+		if x.Reached(s) {
+			return true
+		}
+	}
+	return false
+}
+
+type notGoal struct {
+	goal_ defs.Goal
+}
+
+func (g *notGoal) Reached(s defs.SeqGameState) bool {
+	return !g.goal_.Reached(s)
+}
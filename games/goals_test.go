@@ -0,0 +1,60 @@
+package games
+
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+func TestGoals(t *testing.T) {
+	var g SBGame
+
+	// Pieces 3 and 4 are alike L pieces
+	g.Define(&grids.Matrix2d{
+		[]int{1, 3, 3, 0},
+		[]int{2, 3, 4, 4},
+		[]int{0, 0, 4, 0},
+	})
+	g.AutoAlikePieces()
+	g.SetNotAlikePiece(1)
+	g.SetNotAlikePiece(2)
+	g.Build()
+	s := g.State()
+
+	checks := []struct {
+		name    string
+		reached bool
+		ok      bool
+	}{
+		{"template", Template(&grids.Matrix2d{
+			[]int{1, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+		}).Reached(s), true},
+		{"template, alike piece", Template(&grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 3, 3},
+			[]int{0, 0, 3, 0},
+		}).Reached(s), true},
+		{"empty template", Template(&grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+		}).Reached(s), false},
+		{"piece at", PieceAt(2, 1, 0).Reached(s), true},
+		{"alike piece at", PieceAt(4, 0, 2).Reached(s), true},
+		{"piece not at", PieceAt(1, 1, 0).Reached(s), false},
+		{"piece at, outside", PieceAt(1, 5, 0).Reached(s), false},
+		{"region", Region(2, 0, 0, 1, 0).Reached(s), true},
+		{"partly in region", Region(4, 2, 0, 2, 3).Reached(s), false},
+		{"alike in region", Region(3, 1, 2, 2, 3).Reached(s), true},
+		{"and", And(PieceAt(1, 0, 0), PieceAt(2, 1, 0)).Reached(s), true},
+		{"and, one fails", And(PieceAt(1, 0, 0), PieceAt(2, 0, 0)).Reached(s), false},
+		{"or", Or(PieceAt(1, 1, 0), PieceAt(2, 1, 0)).Reached(s), true},
+		{"or, none", Or(PieceAt(1, 1, 0), PieceAt(2, 0, 0)).Reached(s), false},
+		{"not", Not(PieceAt(1, 1, 0)).Reached(s), true},
+	}
+	for _, c := range checks {
+		if c.reached != c.ok {
+			t.Errorf("Goal '%s': reached %v, expected %v", c.name, c.reached, c.ok)
+		}
+	}
+}
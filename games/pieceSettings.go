@@ -0,0 +1,69 @@
+package games
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Fixed and alike pieces of a sliding blocks game, whatever its grid (SBGame, SB3Game)
+type pieceSettings struct {
+
+	// Pieces that never move, like walls inside the board
+	fixedPieces_ []int
+
+	// Groups of pieces that should be considered alike
+	alikePieces_ [][]int
+
+	// If true, then alike pieces are automatically calculated
+	autoAlikePieces_ bool
+
+	// Set of pieces we want to maintain independent, not alike to other pieces
+	notAutoalikePieces_ []int
+
+	// Piece values used to compare states, shared by all the states of this game
+	pieceToValue_ *defs.PieceToValue
+}
+
+// Alike pieces are pieces with the same shape. Two states with two of
+// these pieces at interchanged positions are considered the same state.
+// This function is used to mark which pieces are equivalent or alike.
+func (g *pieceSettings) AlikePieces(alikePieces [][]int) {
+	g.alikePieces_ = alikePieces
+}
+
+// Marks all pieces with same shape as equivalent
+func (g *pieceSettings) AutoAlikePieces() {
+	g.autoAlikePieces_ = true
+}
+
+func (g *pieceSettings) SetNotAlikePiece(pieceId int) {
+	g.notAutoalikePieces_ = append(g.notAutoalikePieces_, pieceId)
+}
+
+// Marks a piece as fixed: it never moves (walls, holes in the board). Fixed pieces are never alike.
+func (g *pieceSettings) SetFixedPiece(pieceId int) {
+	g.fixedPieces_ = append(g.fixedPieces_, pieceId)
+	g.notAutoalikePieces_ = append(g.notAutoalikePieces_, pieceId)
+}
+
+// True if the piece never moves (see SetFixedPiece)
+func (g *pieceSettings) IsFixed(pieceId int) bool {
+	for _, id := range g.fixedPieces_ {
+		if id == pieceId {
+			return true
+		}
+	}
+	return false
+}
+
+// True if the piece was kept apart from the alike pieces (see SetNotAlikePiece). Fixed pieces are.
+func (g *pieceSettings) IsNotAlike(pieceId int) bool {
+	for _, id := range g.notAutoalikePieces_ {
+		if id == pieceId {
+			return true
+		}
+	}
+	return false
+}
+
+// Value of the piece in state comparisons: alike pieces share it
+func (g *pieceSettings) PieceValue(pieceId int) int {
+	return g.pieceToValue_.At(pieceId)
+}
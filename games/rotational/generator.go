@@ -0,0 +1,137 @@
+package rotational
+
+import "fmt"
+
+// A generator is a permutation of the puzzle slots, written as a set of disjoint cycles. Applying it once
+// moves the piece at cycle[i] to cycle[i+1] (and the last one to cycle[0]).
+// A wheel turn, a ring shift or a face twist are all generators.
+type Generator struct {
+	name_   string
+	cycles_ [][]int
+	order_  int
+}
+
+// Creates a generator over 'numSlots' slots. Cycles must be disjoint and use valid slot indexes.
+func NewGenerator(name string, cycles [][]int, numSlots int) (*Generator, error) {
+	used := make(map[int]bool)
+	g := &Generator{name, nil, 1}
+
+	for _, cycle := range cycles {
+		for _, slot := range cycle {
+			if slot < 0 || slot >= numSlots {
+				return nil, fmt.Errorf("[Generator::NewGenerator] slot %d out of range in '%s'", slot, name)
+			}
+			if used[slot] {
+				return nil, fmt.Errorf("[Generator::NewGenerator] slot %d repeated in '%s'", slot, name)
+			}
+			used[slot] = true
+		}
+
+		// Cycles of length 1 are fixed slots, we don't need them
+		if len(cycle) > 1 {
+			c := make([]int, len(cycle))
+			copy(c, cycle)
+			g.cycles_ = append(g.cycles_, c)
+			g.order_ = lcm(g.order_, len(c))
+		}
+	}
+
+	if len(g.cycles_) == 0 {
+		return nil, fmt.Errorf("[Generator::NewGenerator] '%s' does not move any slot", name)
+	}
+	return g, nil
+}
+
+// Creates the generator of a wheel: 'slots' are the wheel positions in circular order, and each turn
+// advances every piece 'step' positions along the wheel.
+func NewWheelGenerator(name string, slots []int, step int, numSlots int) (*Generator, error) {
+	n := len(slots)
+	if n < 2 {
+		return nil, fmt.Errorf("[Generator::NewWheelGenerator] wheel '%s' needs at least two slots", name)
+	}
+	step = step % n
+	if step < 0 {
+		step += n
+	}
+	if step == 0 {
+		return nil, fmt.Errorf("[Generator::NewWheelGenerator] wheel '%s' step is a full turn", name)
+	}
+
+	// Positions split in gcd(n, step) cycles
+	var cycles [][]int
+	visited := make([]bool, n)
+	for i := 0; i < n; i++ {
+		if visited[i] {
+			continue
+		}
+		var cycle []int
+		for j := i; !visited[j]; j = (j + step) % n {
+			visited[j] = true
+			cycle = append(cycle, slots[j])
+		}
+		cycles = append(cycles, cycle)
+	}
+
+	return NewGenerator(name, cycles, numSlots)
+}
+
+func (g *Generator) Name() string {
+	return g.name_
+}
+
+// Number of times the generator must be applied to get back to the identity.
+func (g *Generator) Order() int {
+	return g.order_
+}
+
+func (g *Generator) Cycles() [][]int {
+	return g.cycles_
+}
+
+// Returns the generator as a permutation: perm[i] is the slot where the piece at slot i goes.
+func (g *Generator) Permutation(numSlots int) []int {
+	perm := make([]int, numSlots)
+	for i := range perm {
+		perm[i] = i
+	}
+	for _, cycle := range g.cycles_ {
+		l := len(cycle)
+		for j, slot := range cycle {
+			perm[slot] = cycle[(j+1)%l]
+		}
+	}
+	return perm
+}
+
+// Moves the pieces in 'slots' applying 'power' times the generator.
+func (g *Generator) Apply(slots []int, power int) {
+	for _, cycle := range g.cycles_ {
+		l := len(cycle)
+		k := power % l
+		if k < 0 {
+			k += l
+		}
+		if k == 0 {
+			continue
+		}
+
+		t := make([]int, l)
+		for j, slot := range cycle {
+			t[j] = slots[slot]
+		}
+		for j := 0; j < l; j++ {
+			slots[cycle[(j+k)%l]] = t[j]
+		}
+	}
+}
+
+func gcd(a int, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func lcm(a int, b int) int {
+	return a / gcd(a, b) * b
+}
@@ -0,0 +1,59 @@
+package rotational
+
+import "fmt"
+
+// ROTATIONAL COMMAND
+// Applies a generator (a wheel turn, a ring shift, a face twist...) a number of times.
+type RotationalCommand struct {
+	generatorId_ int
+	power_       int
+	order_       int
+}
+
+// Creates a command that applies 'power' times the generator. The power is normalized to [0, order).
+func NewRotationalCommand(generatorId int, power int, order int) *RotationalCommand {
+	p := power % order
+	if p < 0 {
+		p += order
+	}
+	return &RotationalCommand{generatorId, p, order}
+}
+
+// Implements command interface
+func (c *RotationalCommand) PieceId() int {
+	return c.generatorId_
+}
+func (c *RotationalCommand) Power() int {
+	return c.power_
+}
+func (c *RotationalCommand) Inverted() interface{} {
+	return NewRotationalCommand(c.generatorId_, -c.power_, c.order_)
+}
+
+func (c *RotationalCommand) IsInverse(m interface{}) bool {
+	mov, ok := m.(*RotationalCommand)
+	if ok {
+		if c.generatorId_ == mov.generatorId_ && (c.power_+mov.power_)%c.order_ == 0 {
+			return true
+		}
+	} else {
+		panic("[RotationalCommand::IsInverse] arg is not a RotationalCommand")
+	}
+	return false
+}
+
+func (c *RotationalCommand) Equals(m interface{}) bool {
+	mov, ok := m.(*RotationalCommand)
+	if ok {
+		if c.generatorId_ == mov.generatorId_ && c.power_%c.order_ == mov.power_%mov.order_ {
+			return true
+		}
+	} else {
+		panic("[RotationalCommand::Equals] arg is not a RotationalCommand")
+	}
+	return false
+}
+
+func (c *RotationalCommand) Print() {
+	fmt.Printf("[%d %d]", c.generatorId_, c.power_)
+}
@@ -0,0 +1,201 @@
+package rotational
+
+import "fmt"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Defines a permutation puzzle: a set of slots holding pieces, and a set of generators (cycles over
+// slot indexes) that move them. Engel wheels, Hungarian rings or Rubik-like puzzles are defined as data.
+//
+// A game is built this way:
+//
+//	var rings RotationalGame
+//	rings.Define([]int{1, 2, 3, ...})
+//	rings.AddWheel("left", []int{0, 1, 2, ...}, 1)
+//	rings.AddGenerator("twist", [][]int{[]int{0, 4, 8}, []int{1, 5, 9}})
+//	rings.SetAlike([][]int{...})
+type RotationalGame struct {
+
+	// Initial state of the game
+	state_ RotationalState
+
+	generators_   []*Generator
+	pieces_       []int
+	pieceToValue_ *defs.PieceToValue
+}
+
+// Defines the initial piece id placed at each slot. All ids must be different and greater than 0.
+func (g *RotationalGame) Define(pieceIds []int) (err error) {
+	found := make(map[int]bool)
+	for _, id := range pieceIds {
+		if id <= 0 {
+			return fmt.Errorf("[RotationalGame::Define] invalid piece id %d", id)
+		}
+		if found[id] {
+			return fmt.Errorf("[RotationalGame::Define] piece id %d repeated", id)
+		}
+		found[id] = true
+	}
+
+	g.state_.Init(pieceIds)
+	g.state_.SetInitial()
+
+	g.pieces_ = make([]int, len(pieceIds))
+	copy(g.pieces_, pieceIds)
+
+	// By default, all pieces are different
+	g.SetAlike(nil)
+	return nil
+}
+
+// Defines a puzzle made of wheels. Each wheel lists, in circular order, the ids of the pieces it holds.
+// A piece id present in two or more wheels is a slot shared by them (an intersection). Each turn of
+// wheel i advances its pieces steps[i] positions.
+func (g *RotationalGame) DefineWheels(wheels [][]int, steps []int) (err error) {
+	if len(wheels) != len(steps) {
+		return fmt.Errorf("[RotationalGame::DefineWheels] %d wheels but %d steps", len(wheels), len(steps))
+	}
+
+	// Slots are numbered by first appearance of their piece
+	slotOf := make(map[int]int)
+	var pieceIds []int
+	for _, wheel := range wheels {
+		for _, id := range wheel {
+			if _, ok := slotOf[id]; !ok {
+				slotOf[id] = len(pieceIds)
+				pieceIds = append(pieceIds, id)
+			}
+		}
+	}
+
+	if err = g.Define(pieceIds); err != nil {
+		return err
+	}
+
+	for i, wheel := range wheels {
+		slots := make([]int, len(wheel))
+		for j, id := range wheel {
+			slots[j] = slotOf[id]
+		}
+		if _, err = g.AddWheel(fmt.Sprintf("wheel %d", i), slots, steps[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Adds a generator given as disjoint cycles of slot indexes. Returns its identifier, used in commands.
+func (g *RotationalGame) AddGenerator(name string, cycles [][]int) (id int, err error) {
+	gen, err := NewGenerator(name, cycles, len(g.pieces_))
+	if err != nil {
+		return -1, err
+	}
+	g.generators_ = append(g.generators_, gen)
+	return len(g.generators_) - 1, nil
+}
+
+// Adds a wheel generator: 'slots' in circular order, each turn advancing 'step' positions.
+func (g *RotationalGame) AddWheel(name string, slots []int, step int) (id int, err error) {
+	gen, err := NewWheelGenerator(name, slots, step, len(g.pieces_))
+	if err != nil {
+		return -1, err
+	}
+	g.generators_ = append(g.generators_, gen)
+	return len(g.generators_) - 1, nil
+}
+
+// Identifies groups of pieces as being interchangeable. Creates a new identifier for each group.
+func (g *RotationalGame) SetAlike(pieceIdGroups [][]int) {
+
+	// The map is shared with all the game states: edit it in place
+	if g.pieceToValue_ == nil {
+		g.pieceToValue_ = defs.NewPieceToValue()
+		g.state_.SetPieceToValue(g.pieceToValue_)
+	}
+
+	maxId := 0
+	for _, id := range g.pieces_ {
+		if id > maxId {
+			maxId = id
+		}
+	}
+
+	// Init map with read ids:
+	for _, id := range g.pieces_ {
+		g.pieceToValue_.Set(id, id)
+	}
+
+	// Then, change identified pieces values by their new group value:
+	for idx, group := range pieceIdGroups {
+		newValue := maxId + idx + 1
+
+		for _, id := range group {
+			g.pieceToValue_.Set(id, newValue)
+		}
+	}
+}
+
+func (g *RotationalGame) Generators() []*Generator {
+	return g.generators_
+}
+
+func (g *RotationalGame) NumSlots() int {
+	return len(g.pieces_)
+}
+
+// Builds a state of this puzzle with the given piece id at each slot. Useful to define targets.
+func (g *RotationalGame) NewState(pieceIds []int) *RotationalState {
+	s := &RotationalState{}
+	s.Init(pieceIds)
+	s.SetPieceToValue(g.pieceToValue_)
+	return s
+}
+
+// Implement Explorable interface
+
+// Apply the movement
+func (g *RotationalGame) Move(m defs.Command) (err error) {
+	mov := m.(*RotationalCommand)
+	g.state_.Move(g.generators_[mov.PieceId()], mov.Power())
+	return nil
+}
+
+// Undoes the movement
+func (g *RotationalGame) UndoMove(mov defs.Command) (err error) {
+	m := mov.Inverted()
+	mInv := m.(defs.Command)
+
+	return g.Move(mInv)
+}
+
+// Makes the playable game to put its internal parts to
+// reflect this state.
+func (g *RotationalGame) SetState(s defs.GameState) (err error) {
+	g.state_.Assign(*(s.(*RotationalState)))
+	return nil
+}
+
+// Returns a copy of current state
+func (g *RotationalGame) State() (s defs.GameState) {
+	return g.state_.Clone()
+}
+
+// Generators are never blocked: every power of every generator is valid. After a move, the same
+// generator is skipped, because any power of it would lead to a state of the same depth or the parent.
+func (g *RotationalGame) ValidMovements() []defs.Command {
+	var movs []defs.Command
+
+	prevId := -1
+	if prevMov := g.state_.PrevMov(); prevMov != nil {
+		prevId = prevMov.PieceId()
+	}
+
+	for id, gen := range g.generators_ {
+		if id == prevId {
+			continue
+		}
+		for p := 1; p < gen.Order(); p++ {
+			movs = append(movs, NewRotationalCommand(id, p, gen.Order()))
+		}
+	}
+	return movs
+}
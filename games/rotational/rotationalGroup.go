@@ -0,0 +1,35 @@
+package rotational
+
+import "math/big"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/groups"
+
+// Group generated by the puzzle generators. Its order is the number of states with all pieces distinct.
+func (g *RotationalGame) Group() *groups.PermGroup {
+	n := g.NumSlots()
+
+	var perms []groups.Perm
+	for _, gen := range g.generators_ {
+		perms = append(perms, groups.Perm(gen.Permutation(n)))
+	}
+	return groups.NewPermGroup(n, perms)
+}
+
+// Piece values (see SetAlike) of a state, by slot.
+func (g *RotationalGame) Coloring(s *RotationalState) []int {
+	coloring := make([]int, len(s.slots_))
+	for i, id := range s.slots_ {
+		coloring[i] = g.pieceToValue_.At(id)
+	}
+	return coloring
+}
+
+// Number of distinct states reachable from the initial state, taking alike pieces into account.
+func (g *RotationalGame) OrbitSize() *big.Int {
+	return g.Group().OrbitSize(g.Coloring(&g.state_))
+}
+
+// Returns true if the target state can be reached from the initial state.
+func (g *RotationalGame) Solvable(target *RotationalState) (bool, error) {
+	return g.Group().Solvable(g.Coloring(&g.state_), g.Coloring(target))
+}
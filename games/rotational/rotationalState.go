@@ -0,0 +1,122 @@
+package rotational
+
+import "fmt"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Rotational puzzle state: the piece placed at each slot.
+type RotationalState struct {
+	// Graph structure
+	uid_       int
+	depth_     int
+	prevMov_   defs.Command
+	isInitial_ bool
+
+	// Structure state: piece id at each slot
+	slots_ []int
+
+	// Utils
+	pieceToValue_ *defs.PieceToValue
+}
+
+func (s *RotationalState) SetInitial() {
+	s.isInitial_ = true
+}
+func (s *RotationalState) Initial() bool {
+	return s.isInitial_
+}
+
+func (s *RotationalState) Init(pieceIds []int) {
+	s.slots_ = make([]int, len(pieceIds))
+	copy(s.slots_, pieceIds)
+	s.depth_ = 0
+}
+
+// Sets the map of piece values, owned by the game
+func (s *RotationalState) SetPieceToValue(ptv *defs.PieceToValue) {
+	s.pieceToValue_ = ptv
+}
+
+func (s *RotationalState) Assign(e RotationalState) {
+	s.uid_ = e.uid_
+	if len(s.slots_) != len(e.slots_) {
+		s.slots_ = make([]int, len(e.slots_))
+	}
+	copy(s.slots_, e.slots_)
+	s.pieceToValue_ = e.pieceToValue_
+	s.depth_ = e.depth_
+	s.prevMov_ = e.prevMov_
+}
+
+// Piece id at the slot
+func (s *RotationalState) At(slot int) int {
+	return s.slots_[slot]
+}
+
+// Returns a copy of the piece ids, by slot
+func (s *RotationalState) Slots() []int {
+	c := make([]int, len(s.slots_))
+	copy(c, s.slots_)
+	return c
+}
+
+// Interface for game states:
+func (s *RotationalState) Uid() int {
+	return s.uid_
+}
+
+func (s *RotationalState) Clone() defs.GameState {
+	var c RotationalState
+
+	c.uid_ = defs.NextUid()
+	c.slots_ = make([]int, len(s.slots_))
+	copy(c.slots_, s.slots_)
+	c.pieceToValue_ = s.pieceToValue_
+	c.depth_ = s.depth_ + 1
+	c.prevMov_ = s.prevMov_
+
+	return &c
+}
+
+func (s *RotationalState) Equal(o defs.GameState) bool {
+	e := o.(*RotationalState)
+	if len(s.slots_) != len(e.slots_) {
+		return false
+	}
+	for i, id := range s.slots_ {
+		if s.pieceToValue_.At(id) != s.pieceToValue_.At(e.slots_[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *RotationalState) ToHash() int {
+	h := 1
+	for _, id := range s.slots_ {
+		h = 31*h + s.pieceToValue_.At(id)
+	}
+	return h
+}
+
+func (s *RotationalState) Print() {
+	fmt.Printf("%v", s.slots_)
+}
+func (s *RotationalState) Depth() int {
+	return s.depth_
+}
+
+func (s *RotationalState) SetPrevMov(m defs.Command) {
+	s.prevMov_ = m
+}
+
+func (s *RotationalState) PrevMov() defs.Command {
+	return s.prevMov_
+}
+
+// Nothing to remember: generators are never blocked, so revisits give no extra information.
+func (s *RotationalState) AddPrevMov(m defs.Command) {
+}
+
+func (s *RotationalState) Move(g *Generator, power int) {
+	g.Apply(s.slots_, power)
+}
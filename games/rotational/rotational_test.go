@@ -0,0 +1,98 @@
+package rotational
+
+import "testing"
+
+// Tests wheel generators: cycles, order and slot movements
+func TestWheelGenerator(t *testing.T) {
+
+	// A 12-slot Engel wheel turning 60 degrees: two cycles of 6
+	slots := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	g, err := NewWheelGenerator("engel", slots, 2, 12)
+	if err != nil {
+		t.Fatalf("NewWheelGenerator failed: %v", err)
+	}
+	if g.Order() != 6 || len(g.Cycles()) != 2 {
+		t.Errorf("Wheel generator: order %d, cycles %d; expected 6 and 2", g.Order(), len(g.Cycles()))
+	}
+
+	pieces := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	g.Apply(pieces, 1)
+	if pieces[2] != 1 || pieces[3] != 2 || pieces[0] != 11 {
+		t.Errorf("Wheel generator: unexpected turn result %v", pieces)
+	}
+	g.Apply(pieces, 5)
+	for i, id := range pieces {
+		if id != i+1 {
+			t.Errorf("Wheel generator: order turns should be the identity, got %v", pieces)
+			break
+		}
+	}
+
+	if _, err := NewGenerator("bad", [][]int{[]int{0, 1}, []int{1, 2}}, 3); err == nil {
+		t.Errorf("NewGenerator should reject non disjoint cycles")
+	}
+}
+
+// Tests that pieces travel between wheels through their shared slots
+func TestSharedSlots(t *testing.T) {
+	var game RotationalGame
+
+	// Two 4-slot wheels sharing the piece 1
+	err := game.DefineWheels([][]int{
+		[]int{1, 2, 3, 4},
+		[]int{1, 5, 6, 7},
+	}, []int{1, 1})
+	if err != nil {
+		t.Fatalf("DefineWheels failed: %v", err)
+	}
+	if game.NumSlots() != 7 {
+		t.Errorf("Expected 7 slots, got %d", game.NumSlots())
+	}
+
+	// Turn first wheel: 4 goes to the shared slot. Then second wheel takes it.
+	game.Move(NewRotationalCommand(0, 1, 4))
+	game.Move(NewRotationalCommand(1, 1, 4))
+
+	s := game.State().(*RotationalState)
+	if s.At(4) != 4 || s.At(0) != 7 {
+		t.Errorf("Unexpected slots after moves: %v", s.Slots())
+	}
+
+	// Undo everything
+	game.UndoMove(NewRotationalCommand(1, 1, 4))
+	game.UndoMove(NewRotationalCommand(0, 1, 4))
+	s = game.State().(*RotationalState)
+	if !s.Equal(game.NewState([]int{1, 2, 3, 4, 5, 6, 7})) {
+		t.Errorf("Undo should restore the initial state: %v", s.Slots())
+	}
+
+	// First move: all powers of both wheels
+	if n := len(game.ValidMovements()); n != 6 {
+		t.Errorf("Expected 6 valid movements, got %d", n)
+	}
+}
+
+// Tests group order, alike-aware orbit size and solvability of a single wheel
+func TestWheelGroup(t *testing.T) {
+	var game RotationalGame
+
+	game.Define([]int{1, 2, 3, 4, 5, 6})
+	game.AddWheel("wheel", []int{0, 1, 2, 3, 4, 5}, 1)
+
+	if order := game.Group().Order().Int64(); order != 6 {
+		t.Errorf("Wheel group order: %d, should be 6", order)
+	}
+
+	if ok, _ := game.Solvable(game.NewState([]int{6, 1, 2, 3, 4, 5})); !ok {
+		t.Errorf("A turned wheel should be solvable")
+	}
+	if ok, _ := game.Solvable(game.NewState([]int{2, 1, 3, 4, 5, 6})); ok {
+		t.Errorf("Swapped pieces should not be solvable")
+	}
+
+	// Alternate colors: only two distinct states
+	game.SetAlike([][]int{[]int{1, 3, 5}, []int{2, 4, 6}})
+	if size := game.OrbitSize().Int64(); size != 2 {
+		t.Errorf("Wheel orbit size: %d, should be 2", size)
+	}
+}
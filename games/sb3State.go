@@ -0,0 +1,250 @@
+package games
+
+import "fmt"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// 3D Sliding Blocks Puzzle Game State: the path and search bookkeeping of SBPState, on a voxel grid
+type SB3State struct {
+	seqState
+	grid *grids.Matrix3d
+}
+
+// Initialize the game with the starting state grid
+func (g *SB3State) Init(m *grids.Matrix3d) {
+	g.uid_ = defs.NextUid()
+	g.grid = m.Clone()
+	g.prevState_ = nil
+}
+
+func (g *SB3State) CopyGrid(s SB3State) {
+	g.grid = s.grid.Clone()
+}
+
+// Returns the state grid. Must not be modified.
+func (g *SB3State) Grid() *grids.Matrix3d {
+	return g.grid
+}
+
+// Index (in reading order: layer, row, column) of the first cell of the piece, -1 if the piece is not
+// on the grid. Voxel grids have no symmetries: sym must be the identity.
+func (g *SB3State) FirstCell(pieceId int, sym grids.Symmetry) int {
+	if sym != grids.IDENTITY {
+		panic("[SB3State::FirstCell] symmetries are not supported on voxel grids!")
+	}
+	rows := g.grid.Rows()
+	cols := g.grid.Cols()
+	for l, layer := range *g.grid {
+		for r, row := range layer {
+			for c, id := range row {
+				if id == pieceId {
+					return (l*rows+r)*cols + c
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// Id of the piece covering the cell, given by its index in reading order. 0 if empty.
+func (g *SB3State) PieceAtCell(cell int) int {
+	rows := g.grid.Rows()
+	cols := g.grid.Cols()
+	return g.grid.At(cell/(rows*cols), cell/cols%rows, cell%cols)
+}
+
+func (g *SB3State) UpdatePiecePositions(piecesById map[int]*grids.GridPiece3) {
+	g.grid.UpdatePiecePositions(piecesById)
+}
+
+func (g *SB3State) TinyPrint() {
+	fmt.Printf("\n [id:%d] depth:%d, GRID: %v\n", g.uid_, g.depth_, *g.grid)
+	g.printPath()
+}
+
+func (g *SB3State) TinyGoPrint() {
+	fmt.Printf("\n [id:%d] depth:%d, GRID: ", g.uid_, g.depth_)
+
+	for _, layer := range *g.grid {
+		fmt.Printf("\n [][]int{")
+		for _, row := range layer {
+			fmt.Printf("\n  []int{")
+			for c, v := range row {
+				if c == 0 {
+					fmt.Printf("%d", v)
+				} else {
+					fmt.Printf(", %d", v)
+				}
+			}
+			fmt.Printf("},")
+		}
+		fmt.Printf("\n },")
+	}
+	fmt.Printf("\n")
+	g.printGoPath()
+}
+
+/**
+ * Implement defs.SeqGameState interface
+ */
+
+// Create a new defs.SeqGameState, cloned from current
+func (g *SB3State) Clone() defs.SeqGameState {
+	var c SB3State
+
+	c.uid_ = defs.NextUid()
+	c.grid = g.grid.Clone()
+	c.pieceToValue_ = g.pieceToValue_
+	c.prevState_ = nil
+	return &c
+}
+
+func (g *SB3State) Equal(c defs.SeqGameState) bool {
+	s2, ok := c.(*SB3State)
+	if ok {
+		return g.grid.SameValues(s2.grid, g.values())
+	}
+	return true
+}
+
+func (g *SB3State) EqualSub(c defs.SeqGameState) bool {
+	s2, ok := c.(*SB3State)
+	if ok {
+		return g.grid.SubValues(s2.grid, g.values())
+	}
+	return false
+}
+
+// Generate an integer from the current position of pieces
+func (g *SB3State) ToHash() int {
+	return g.grid.HashValues(g.values())
+}
+
+// After doing a reparent, we have to update
+func (s *SB3State) UpdateFromPrevState() {
+	prev := s.prevState_.(*SB3State)
+	s.grid = prev.grid.Clone()
+	s.applyMov(s.prevMov_)
+}
+
+// Checks only from prev
+func (s *SB3State) CheckPathAndState() {
+	var tempState SB3State
+
+	prev := s.prevState_.(*SB3State)
+	tempState.grid = prev.grid.Clone()
+	tempState.applyMov(s.prevMov_)
+
+	// Now should be identical, not only equivalent!
+	if !tempState.grid.Identical(s.grid) {
+		fmt.Println("\n *** CheckPathAndState FAILED *** ")
+
+		fmt.Println("\n SHOULD BE:", *tempState.grid)
+		fmt.Println("\n CURRENT:", *s.grid)
+
+		panic("STOP")
+	}
+}
+
+func (s *SB3State) SetMovChain(chain *defs.MovChain, updateStateFromPath *defs.SeqGameState) {
+	s.movChain_ = chain
+
+	if updateStateFromPath != nil {
+		s.UpdateFromStart(updateStateFromPath)
+	}
+}
+
+// Chains are never modified, so they are shared
+func (s *SB3State) CopyMovChainFrom(gs defs.SeqGameState) {
+	from, ok := gs.(*SB3State)
+	if ok {
+		s.movChain_ = from.movChain_
+	}
+}
+
+// Sets a new path to the state, adding a final step, and updates its real length
+func (s *SB3State) CopyMovChainAndAdd(path *defs.MovChain, mov defs.Command, updateStateFromPath *defs.SeqGameState) {
+	s.movChain_ = path.Add(mov)
+
+	if updateStateFromPath != nil {
+		s.UpdateFromStart(updateStateFromPath)
+	}
+}
+
+// Removes the state and restarts: it starts with the original state and then applies the chain of movs.
+func (s *SB3State) UpdateFromStart(originState *defs.SeqGameState) {
+	o := (*originState).(*SB3State)
+	s.grid = o.grid.Clone()
+
+	for _, mov := range s.movChain_.Slice() {
+		s.applyMov(mov)
+	}
+}
+
+// Builds a set of pieces from current state
+func (s *SB3State) BuildPieces(pieces *[]*grids.GridPiece3, alikePieces [][]int, notAutoalikePieces []int) int {
+	n := s.grid.GeneratePieces(pieces, alikePieces)
+	setPieceValues(&s.seqState, *pieces, notAutoalikePieces)
+	return n
+}
+
+func (s *SB3State) DetectAlikePieces(pieces []*grids.GridPiece3, notAutoalikePieces []int) {
+	grids.DetectAlikePieces3(pieces, s.grid.Max()+1)
+	setPieceValues(&s.seqState, pieces, notAutoalikePieces)
+}
+
+func (s *SB3State) ClearPiece(p *grids.GridPiece3) {
+	s.grid.ClearPiece(p)
+}
+
+func (s *SB3State) PlacePiece(p *grids.GridPiece3) {
+	s.grid.PlacePiece(p)
+}
+
+// We need to update a state from the original state.
+func (s *SB3State) applyMov(mov defs.Command) {
+	gMov, ok := mov.(*grids.GridMov3)
+	if !ok || gMov == nil {
+		panic("[SB3State::applyMov] mov is not a GridMov3!")
+	}
+	s.grid.ApplyRawTranslation(mov.PieceId(), *gMov)
+}
+
+/**
+ * ====================== BFS ===============================
+ */
+func (s *SB3State) ValidMovementsBFS(pieces []*grids.GridPiece3, pieceTrajectory []defs.Command) []defs.Command {
+
+	// Prioritize consecutive movements with the same piece
+	var samePieceMovs []defs.Command
+	var otherMovs []defs.Command
+	pieceId := 0
+	if s.prevMov_ != nil {
+		pieceId = s.prevMov_.PieceId()
+	}
+
+	for _, p := range pieces {
+		for _, m := range s.grid.PieceMovements(p) {
+			if pieceId == 0 || pieceId != p.Id() {
+				otherMovs = append(otherMovs, m)
+				continue
+			}
+
+			// When moving one piece consecutively, avoid trajectories that touch themselves
+			if !m.IsInverse(s.prevMov_) && !grids.TrajectoryTouchesWithMov3(pieceTrajectory, m) {
+				samePieceMovs = append(samePieceMovs, m)
+			}
+		}
+	}
+
+	return append(samePieceMovs, otherMovs...)
+}
+
+// Returns true if the movement m can be applied to the state
+func (s *SB3State) ValidMovement(mov defs.Command) bool {
+	gMov, ok := mov.(*grids.GridMov3)
+	if !ok || gMov == nil {
+		panic("[SB3State::ValidMovement] mov is not a GridMov3!")
+	}
+	return s.grid.ValidMove(*gMov)
+}
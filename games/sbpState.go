@@ -4,57 +4,58 @@ import "fmt"
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
 
-var staticSBPStateCount_ int = 0
-
 // Sliding Blocks Puzzle Game State
 type SBPState struct {
-	uid_              int
-	grid              grids.Matrix2d
-	movChain_         []defs.Command
-	movChainCount_    int
-	pieceToValue_     *defs.PieceToValue
-	equivToObjective_ bool
+	seqState
+	grid     grids.Board
+	lattice_ grids.Lattice
 	//originState_      *grids.Matrix2d
 
-	// BFS
-	waiting_       bool
-	depth_         int
-	equivalencies_ []struct {
-		state_ defs.SeqGameState
-		path_  []defs.Command
-		mov_   defs.Command
-	}
-	markedDebug_ bool
-
 	// Graph structure
-	prevState_  defs.SeqGameState
-	prevMov_    defs.Command
 	nextStates_ []defs.SeqGameState
 	nextMovs_   []defs.Command
 }
 
 // Initialize the game with the starting state matrix
 func (g *SBPState) Init(m *grids.Matrix2d) {
-	staticSBPStateCount_++
-	g.uid_ = staticSBPStateCount_
-	g.grid.Copy(m)
+	g.uid_ = defs.NextUid()
+	g.grid = m.CloneBoard()
 	g.prevState_ = nil
 }
 
 func (g *SBPState) CopyGrid(s SBPState) {
-	g.grid.Copy(&s.grid)
+	g.grid = s.grid.CloneBoard()
 }
 
-func (g *SBPState) UpdatePiecePositions(piecesById map[int]*grids.GridPiece2) {
-	g.grid.UpdatePiecePositions(piecesById)
+// Returns the state grid. Must not be modified. Boards storing piece positions build it on each call.
+func (g *SBPState) Grid() *grids.Matrix2d {
+	return g.grid.Matrix()
 }
 
-func (g *SBPState) MarkToDebug() {
-	g.markedDebug_ = true
+// Index (in reading order) of the first cell of the piece in the image of the grid by the symmetry, -1
+// if the piece is not on the grid. Does not build the grid matrix.
+func (g *SBPState) FirstCell(pieceId int, sym grids.Symmetry) int {
+	rows := g.grid.Rows()
+	cols := g.grid.Cols()
+
+	first := -1
+	for _, cell := range g.grid.PieceCells(pieceId) {
+		r, c := sym.Cell(rows, cols, cell[0], cell[1])
+		if i := r*cols + c; first < 0 || i < first {
+			first = i
+		}
+	}
+	return first
 }
 
-func (g *SBPState) MarkedToDebug() bool {
-	return g.markedDebug_
+// Id of the piece covering the cell, given by its index in reading order. 0 if empty.
+func (g *SBPState) PieceAtCell(cell int) int {
+	cols := g.grid.Cols()
+	return g.grid.At(cell/cols, cell%cols)
+}
+
+func (g *SBPState) UpdatePiecePositions(piecesById map[int]*grids.GridPiece2) {
+	g.grid.UpdatePiecePositions(piecesById)
 }
 
 // // Initialize the game with the starting state matrix
@@ -73,19 +74,10 @@ func (g *SBPState) MarkedToDebug() bool {
 
 // Initialize the game with the starting state matrix
 func (g *SBPState) TinyPrint() {
-	fmt.Printf("\n [id:%d] depth:%d, GRID: %v\n", g.uid_, g.depth_, g.grid)
-	fmt.Printf(" PATH<%d>:", g.movChainCount_)
+	fmt.Printf("\n [id:%d] depth:%d, GRID: %v\n", g.uid_, g.depth_, *g.grid.Matrix())
 
 	// Json format
-	fmt.Print("[")
-	for idx, m := range g.movChain_ {
-		if idx > 0 {
-			fmt.Print(",")
-		}
-		m.Print()
-	}
-	fmt.Print("]")
-
+	g.printPath()
 }
 
 func (g *SBPState) TinyGoPrint() {
@@ -106,142 +98,52 @@ func (g *SBPState) TinyGoPrint() {
 	}
 	fmt.Printf("\n")
 
-	fmt.Printf(" PATH<%d>:", g.movChainCount_)
-
-	for _, m := range g.movChain_ {
-		fmt.Print(" ")
-		m.Print()
-	}
-
+	g.printGoPath()
 }
 
 /**
  * Implement defs.SeqGameState interface
  */
-func (g *SBPState) Uid() int {
-	return g.uid_
-}
 
 // Create a new defs.SeqGameState, cloned from current
 func (g *SBPState) Clone() defs.SeqGameState {
 	var c SBPState
 
-	staticSBPStateCount_++
-	c.uid_ = staticSBPStateCount_
-	c.grid.Copy(&g.grid)
+	c.uid_ = defs.NextUid()
+	c.grid = g.grid.CloneBoard()
+	c.pieceToValue_ = g.pieceToValue_
+	c.lattice_ = g.lattice_
 	c.prevState_ = nil
 	return &c
 }
 
 //
 func (g *SBPState) Equal(c defs.SeqGameState) bool {
-	if g.pieceToValue_ == nil {
-		g.pieceToValue_ = defs.GetPieceToValueMap()
-	}
-	//fmt.Println("[SBPState::Equal]", g.pieceToValue_)
-
-	rows := g.grid.Rows()
-	cols := g.grid.Cols()
-
 	s2, ok := c.(*SBPState)
 	if ok {
-		for i := 0; i < rows; i++ {
-			for j := 0; j < cols; j++ {
-				if g.pieceToValue_.At(g.grid[i][j]) != g.pieceToValue_.At(s2.grid[i][j]) {
-					//fmt.Println("Different states:")
-					//fmt.Printf("	At cell (%d,%d) values are %d and %d", i, j, g.pieceToValue_[g.grid[i][j]], g.pieceToValue_[s2.grid[i][j]])
-					return false
-				}
-			}
-		}
+		return g.grid.SameValues(s2.grid, g.values())
 	}
 	return true
 }
 
 func (g *SBPState) EqualSub(c defs.SeqGameState) bool {
-	if g.pieceToValue_ == nil {
-		g.pieceToValue_ = defs.GetPieceToValueMap()
-	}
-
-	rows := g.grid.Rows()
-	cols := g.grid.Cols()
-
-	anyPieceFound := false
 	s2, ok := c.(*SBPState)
 	if ok {
-		for i := 0; i < rows; i++ {
-			for j := 0; j < cols; j++ {
-
-				// Only check pieces from 'c' state
-				x := g.pieceToValue_.At(s2.grid[i][j])
-				if x > 0 {
-					anyPieceFound = true
-					if g.pieceToValue_.At(g.grid[i][j]) != x {
-						return false
-					}
-				}
-			}
-		}
+		return g.grid.SubValues(s2.grid, g.values())
 	}
-	return anyPieceFound
-}
-
-func (g *SBPState) MarkAsObjective() {
-	g.equivToObjective_ = true
-}
-
-func (g *SBPState) IsObjective() bool {
-	return g.equivToObjective_
+	return false
 }
 
 // Generate an integer from the current position of pieces
 func (g *SBPState) ToHash() int {
-	rows := g.grid.Rows()
-	cols := g.grid.Cols()
-	if g.pieceToValue_ == nil {
-		g.pieceToValue_ = defs.GetPieceToValueMap()
-	}
-
-	hash := 0
-
-	for i := 0; i < rows; i++ {
-		for j := 0; j < cols; j++ {
-			hash += (i + 1) * (rows*cols + j) * g.pieceToValue_.At(g.grid[i][j])
-		}
-	}
-	return hash
-}
-
-func (s *SBPState) PrevState() defs.SeqGameState {
-	return s.prevState_
-}
-
-// Sets prev status and the mov that brought to this state
-func (s *SBPState) SetPrevState(prev defs.SeqGameState, mov defs.Command) {
-	var ss *SBPState = nil
-
-	if prev == nil {
-		s.depth_ = 0
-	} else {
-		ss = prev.(*SBPState)
-		s.depth_ = ss.depth_ + 1
-	}
-
-	s.prevState_ = prev
-	s.prevMov_ = mov
-
-	// // Used in BFS
-	// if ss != nil {
-	// 	ss.nextMovs_ = append(ss.nextMovs_, mov)
-	// }
-
+	return g.grid.HashValues(g.values())
 }
 
 // After doing a reparent, we have to update
 func (s *SBPState) UpdateFromPrevState() {
 
 	prev := s.prevState_.(*SBPState)
-	s.grid.Copy(&prev.grid)
+	s.grid = prev.grid.CloneBoard()
 
 	// And apply last mov
 	s.applyMov(s.prevMov_)
@@ -252,27 +154,23 @@ func (s *SBPState) CheckPathAndState() {
 	var tempState SBPState
 
 	prev := s.prevState_.(*SBPState)
-	tempState.grid.Copy(&prev.grid)
+	tempState.grid = prev.grid.CloneBoard()
 
 	// And apply last mov
 	tempState.applyMov(s.prevMov_)
 
 	// Now should be identical, not only equivalent!
-	if !tempState.grid.Identical(s.grid) {
+	if !tempState.grid.Matrix().Identical(*s.grid.Matrix()) {
 		fmt.Println("\n *** CheckPathAndState FAILED *** ")
 
-		fmt.Println("\n SHOULD BE:", tempState.grid)
-		fmt.Println("\n CURRENT:", s.grid)
+		fmt.Println("\n SHOULD BE:", *tempState.grid.Matrix())
+		fmt.Println("\n CURRENT:", *s.grid.Matrix())
 
 		panic("STOP")
 	}
 
 }
 
-func (s *SBPState) PrevMov() defs.Command {
-	return s.prevMov_
-}
-
 // // Adds a state as a next state, and the corresponding mov
 // func (s *SBPState) AddNextState(u defs.SeqGameState, mov defs.Command) {
 // 	s.nextStates_ = append(s.nextStates_, u)
@@ -290,57 +188,25 @@ func (s *SBPState) PrevMov() defs.Command {
 // 	return false
 // }
 
-func (s *SBPState) SetMovChain(movs []defs.Command, updateStateFromPath *defs.SeqGameState) {
-	s.movChain_ = movs
-	s.updateChainLen()
+func (s *SBPState) SetMovChain(chain *defs.MovChain, updateStateFromPath *defs.SeqGameState) {
+	s.movChain_ = chain
 
 	if updateStateFromPath != nil {
 		s.UpdateFromStart(updateStateFromPath)
 	}
 }
 
-func (s *SBPState) updateChainLen() {
-	// Two or more consecutive movs on the same piece count as only one movement!
-	s.movChainCount_ = 0
-	lastPieceId := 0
-	for _, m := range s.movChain_ {
-		if lastPieceId != m.PieceId() {
-			s.movChainCount_++
-			lastPieceId = m.PieceId()
-		}
-	}
-}
-
-func (s *SBPState) SetDepth(d int) {
-	s.depth_ = d
-}
-func (s *SBPState) Depth() int {
-	return s.depth_
-}
-
-func (s *SBPState) CollapsedPathLen() int {
-	return s.movChainCount_
-}
-
-func (s *SBPState) RealPathLen() int {
-	return len(s.movChain_)
-}
-
+// Chains are never modified, so they are shared
 func (s *SBPState) CopyMovChainFrom(gs defs.SeqGameState) {
 	from, ok := gs.(*SBPState)
 	if ok {
-		s.movChain_ = make([]defs.Command, len(from.movChain_))
-		copy(s.movChain_, from.movChain_)
-		s.updateChainLen()
+		s.movChain_ = from.movChain_
 	}
 }
 
 // Sets a new path to the state, adding a final step, and updates its real length
-func (s *SBPState) CopyMovChainAndAdd(path []defs.Command, mov defs.Command, updateStateFromPath *defs.SeqGameState) {
-	s.movChain_ = make([]defs.Command, len(path))
-	copy(s.movChain_, path)
-	s.movChain_ = append(s.movChain_, mov)
-	s.updateChainLen()
+func (s *SBPState) CopyMovChainAndAdd(path *defs.MovChain, mov defs.Command, updateStateFromPath *defs.SeqGameState) {
+	s.movChain_ = path.Add(mov)
 
 	if updateStateFromPath != nil {
 		s.UpdateFromStart(updateStateFromPath)
@@ -353,11 +219,11 @@ func (s *SBPState) UpdateFromStart(originState *defs.SeqGameState) {
 	//fmt.Println("\t**** UpdateFromStart ****")
 
 	o := (*originState).(*SBPState)
-	s.grid.Copy(&o.grid)
+	s.grid = o.grid.CloneBoard()
 
 	// fmt.Println("Origin grid:", s.grid)
 	// fmt.Println("Path: [")
-	for _, mov := range s.movChain_ {
+	for _, mov := range s.movChain_.Slice() {
 
 		// if idx > 0 {
 		// 	fmt.Printf(",")
@@ -370,35 +236,14 @@ func (s *SBPState) UpdateFromStart(originState *defs.SeqGameState) {
 
 // Builds a set of pieces from current state
 func (s *SBPState) BuildPieces(pieces *[]*grids.GridPiece2, alikePieces [][]int, notAutoalikePieces []int) int {
-	n := s.grid.GeneratePieces(pieces, alikePieces)
-	s.updatePieceToValue(*pieces, notAutoalikePieces)
+	n := s.grid.Matrix().GeneratePieces(pieces, alikePieces)
+	setPieceValues(&s.seqState, *pieces, notAutoalikePieces)
 	return n
 }
 
 func (s *SBPState) DetectAlikePieces(pieces []*grids.GridPiece2, notAutoalikePieces []int) {
-	grids.DetectAlikePieces(pieces, s.grid.Max()+1)
-	s.updatePieceToValue(pieces, notAutoalikePieces)
-}
-
-func (s *SBPState) updatePieceToValue(pieces []*grids.GridPiece2, notAutoalikePieces []int) {
-
-	// Edit not-autoalike piece values
-	for _, id := range notAutoalikePieces {
-		for _, q := range pieces {
-			if q.Id() == id {
-				q.SetValue(q.Id())
-				break
-			}
-		}
-	}
-
-	// Assign the map
-	s.pieceToValue_ = defs.GetPieceToValueMap()
-
-	// Finally edit the map
-	for _, p := range pieces {
-		s.pieceToValue_.Set(p.Id(), p.Value())
-	}
+	grids.DetectLatticeAlikePieces(pieces, s.grid.Matrix().Max()+1, s.lattice_)
+	setPieceValues(&s.seqState, pieces, notAutoalikePieces)
 }
 
 func (s *SBPState) ClearPiece(p *grids.GridPiece2) {
@@ -414,6 +259,11 @@ func (s *SBPState) applyMov(mov defs.Command) (err error) {
 
 	pieceId := mov.PieceId()
 
+	if cMov, ok := mov.(*grids.CompoundMov2); ok {
+		s.grid.ApplyRawTranslation(pieceId, cMov.Mov())
+		return nil
+	}
+
 	gMov, ok := mov.(*grids.GridMov2)
 	if !ok || gMov == nil {
 		panic("[SBPState::applyMov] mov is not a GridMov2!")
@@ -426,14 +276,6 @@ func (s *SBPState) applyMov(mov defs.Command) (err error) {
 	return nil
 }
 
-func (s *SBPState) BuildPathReversed(path *[]defs.Command) {
-
-	if s.prevState_ != nil {
-		*path = append(*path, s.prevMov_)
-		s.prevState_.BuildPathReversed(path)
-	}
-}
-
 /**
  * ====================== BFS ===============================
  */
@@ -450,14 +292,14 @@ func (s *SBPState) ValidMovementsBFS(pieces []*grids.GridPiece2, pieceTrajectory
 
 	for _, p := range pieces {
 
-		movs := s.grid.PieceMovements(p)
+		movs := grids.LatticeMovements(s.grid, p, s.lattice_)
 
 		if pieceId > 0 && pieceId == p.Id() {
 			for _, m := range movs {
 				if !m.IsInverse(s.prevMov_) {
 
 					// We need to avoid, when moving one piece consecutively, trajectories that touch themselves!
-					if len(pieceTrajectory) == 0 || !grids.TrajectoryTouchesWithMov(pieceTrajectory, m) {
+					if len(pieceTrajectory) == 0 || !grids.LatticeTrajectoryTouchesWithMov(s.lattice_, pieceTrajectory, m) {
 						samePieceMovs = append(samePieceMovs, m)
 					}
 				}
@@ -479,26 +321,16 @@ func (s *SBPState) ValidMovementsBFS(pieces []*grids.GridPiece2, pieceTrajectory
 	return seq
 }
 
-func (s *SBPState) SetWaiting(b bool) {
-	s.waiting_ = b
-}
-func (s *SBPState) Waiting() bool {
-	return s.waiting_
-}
-
-func (s *SBPState) AddEquivPath(a defs.SeqGameState, path []defs.Command, m defs.Command) {
-
-	// Duplicate path
-	p := make([]defs.Command, len(path))
-	copy(p, path)
-
-	// Add to equivalences
-	s.equivalencies_ = append(s.equivalencies_,
-		struct {
-			state_ defs.SeqGameState
-			path_  []defs.Command
-			mov_   defs.Command
-		}{a, p, m})
+// Whole piece moves: every position each piece can reach with the other pieces fixed (see
+// grids.LatticeReachableMovs)
+func (s *SBPState) CompoundMovements(pieces []*grids.GridPiece2) []defs.Command {
+	var seq []defs.Command
+	for _, p := range pieces {
+		for _, m := range grids.LatticeReachableMovs(s.grid, p, s.lattice_) {
+			seq = append(seq, m)
+		}
+	}
+	return seq
 }
 
 // Returns true if the movement m can be applied to the state
@@ -510,59 +342,3 @@ func (s *SBPState) ValidMovement(mov defs.Command) bool {
 
 	return s.grid.ValidMove(*gMov)
 }
-
-// Returns true if current movement is on the same piece as an equivalent position of this state.
-// It indicates that the 'other' path is shorter (the length is checked when adding the equivalency)
-func (s *SBPState) ApplyEquivalencyContinuity(a defs.SeqGameState, mov defs.Command, origin defs.SeqGameState) bool {
-
-	//fmt.Println("[ApplyEquivalencyContinuity]")
-
-	// If two states are equivalent, that doesn't means all pieces are equally positionated. It only means that
-	// all alike pieces are at the same position. Here we are going to update the path to a concrete state with
-	// the path of an equivalence state. Then, we may end up with an inconsistency between alike pieces, since that
-	// new path will bring to the same 'equivalent' state, but with undetermined alike piece switches!
-	// To solve this, we need to update the state from the path.
-	updateStateFromPath := &origin
-
-	for _, x := range s.equivalencies_ {
-
-		//fmt.Printf("\n\t Equivalency update. MOV (%v), GRID: %v", x.mov_, x.state_.(*SBPState).grid)
-
-		m := x.mov_
-
-		// x.mov_ is going to be the previous movement to reach state a, so we should ensure that it can be undone on
-		// that state:
-		//mInv := x.mov_.Inverted().(defs.Command)
-
-		//if m.PieceId() == mov.PieceId() && !m.IsInverse(mov) && x.state_.ValidMovement(mov) && a.ValidMovement(mInv) {
-		//if m.PieceId() == mov.PieceId() && x.state_.ValidMovement(mov) && a.ValidMovement(mInv) {
-		if m.PieceId() == mov.PieceId() && !m.IsInverse(mov) && x.state_.ValidMovement(mov) {
-
-			// DO NOT MODIFY S, the prev state:
-			//s is the prev state of a.
-			//s.SetMovChain(x.path_, updateStateFromPath)
-
-			//a state has that path plus mov.
-			a.CopyMovChainAndAdd(x.path_, mov, updateStateFromPath)
-
-			// REPARENT:
-			//a.SetPrevState(x.state_, x.mov_)
-			a.SetPrevState(x.state_, mov)
-
-			// a should have a correct state because we've updated it from the origin!
-			//a.CheckPathAndState()
-
-			//a.UpdateFromStart(updateStateFromPath)
-
-			//a.UpdateFromPrevState() // Now we should update a's grid state!! Because its new parent may have alike pieces switched!!!
-			//a.CheckPathAndState()
-
-			return true
-		}
-	}
-	return false
-}
-
-func (s *SBPState) PathChain() []defs.Command {
-	return s.movChain_
-}
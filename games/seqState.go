@@ -0,0 +1,226 @@
+package games
+
+import "fmt"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Path and search bookkeeping shared by the sliding blocks states, whatever their grid (SBPState, SB3State)
+type seqState struct {
+	uid_              int
+	movChain_         *defs.MovChain
+	pieceToValue_     *defs.PieceToValue
+	equivToObjective_ bool
+
+	// BFS
+	waiting_       bool
+	depth_         int
+	equivalencies_ []struct {
+		state_ defs.SeqGameState
+		path_  *defs.MovChain
+		mov_   defs.Command
+	}
+	markedDebug_ bool
+
+	// Graph structure
+	prevState_  defs.SeqGameState
+	prevMov_    defs.Command
+	parentPath_ *defs.MovChain
+}
+
+// Sets the map of piece values, owned by the game
+func (s *seqState) SetPieceToValue(ptv *defs.PieceToValue) {
+	s.pieceToValue_ = ptv
+}
+
+// Value of a piece id, used for comparisons
+func (s *seqState) value(id int) int {
+	if s.pieceToValue_ == nil {
+		return id
+	}
+	return s.pieceToValue_.At(id)
+}
+
+// The map of piece values. Standalone states get their own.
+func (s *seqState) values() *defs.PieceToValue {
+	if s.pieceToValue_ == nil {
+		s.pieceToValue_ = defs.NewPieceToValue()
+	}
+	return s.pieceToValue_
+}
+
+func (s *seqState) MarkToDebug() {
+	s.markedDebug_ = true
+}
+
+func (s *seqState) MarkedToDebug() bool {
+	return s.markedDebug_
+}
+
+// Prints the path, in json format
+func (s *seqState) printPath() {
+	fmt.Printf(" PATH<%d>:", s.movChain_.CollapsedLen())
+
+	fmt.Print("[")
+	for idx, m := range s.movChain_.Slice() {
+		if idx > 0 {
+			fmt.Print(",")
+		}
+		m.Print()
+	}
+	fmt.Print("]")
+}
+
+// Prints the path, one movement after the other
+func (s *seqState) printGoPath() {
+	fmt.Printf(" PATH<%d>:", s.movChain_.CollapsedLen())
+
+	for _, m := range s.movChain_.Slice() {
+		fmt.Print(" ")
+		m.Print()
+	}
+}
+
+func (s *seqState) Uid() int {
+	return s.uid_
+}
+
+func (s *seqState) MarkAsObjective() {
+	s.equivToObjective_ = true
+}
+
+func (s *seqState) IsObjective() bool {
+	return s.equivToObjective_
+}
+
+func (s *seqState) PrevState() defs.SeqGameState {
+	return s.prevState_
+}
+
+// Sets prev status and the mov that brought to this state
+func (s *seqState) SetPrevState(prev defs.SeqGameState, mov defs.Command) {
+	if prev == nil {
+		s.depth_ = 0
+	} else {
+		s.depth_ = prev.Depth() + 1
+	}
+
+	s.prevState_ = prev
+	s.prevMov_ = mov
+	s.parentPath_ = nil
+}
+
+func (s *seqState) PrevMov() defs.Command {
+	return s.prevMov_
+}
+
+func (s *seqState) MovChain() *defs.MovChain {
+	return s.movChain_
+}
+
+// Path following the previous states. Built once: states are only reparented before being explored.
+func (s *seqState) ParentPath() *defs.MovChain {
+	if s.parentPath_ == nil && s.prevState_ != nil {
+		s.parentPath_ = s.prevState_.ParentPath().Add(s.prevMov_)
+	}
+	return s.parentPath_
+}
+
+func (s *seqState) SetDepth(d int) {
+	s.depth_ = d
+}
+func (s *seqState) Depth() int {
+	return s.depth_
+}
+
+func (s *seqState) CollapsedPathLen() int {
+	return s.movChain_.CollapsedLen()
+}
+
+func (s *seqState) RealPathLen() int {
+	return s.movChain_.Len()
+}
+
+func (s *seqState) BuildPathReversed(path *[]defs.Command) {
+
+	if s.prevState_ != nil {
+		*path = append(*path, s.prevMov_)
+		s.prevState_.BuildPathReversed(path)
+	}
+}
+
+func (s *seqState) SetWaiting(b bool) {
+	s.waiting_ = b
+}
+func (s *seqState) Waiting() bool {
+	return s.waiting_
+}
+
+func (s *seqState) AddEquivPath(a defs.SeqGameState, path *defs.MovChain, m defs.Command) {
+
+	// Add to equivalences. Chains are never modified, no need to duplicate the path
+	s.equivalencies_ = append(s.equivalencies_,
+		struct {
+			state_ defs.SeqGameState
+			path_  *defs.MovChain
+			mov_   defs.Command
+		}{a, path, m})
+}
+
+// Returns true if current movement is on the same piece as an equivalent position of this state.
+// It indicates that the 'other' path is shorter (the length is checked when adding the equivalency)
+func (s *seqState) ApplyEquivalencyContinuity(a defs.SeqGameState, mov defs.Command, origin defs.SeqGameState) bool {
+
+	// If two states are equivalent, that doesn't means all pieces are equally positionated. It only means that
+	// all alike pieces are at the same position. Here we are going to update the path to a concrete state with
+	// the path of an equivalence state. Then, we may end up with an inconsistency between alike pieces, since that
+	// new path will bring to the same 'equivalent' state, but with undetermined alike piece switches!
+	// To solve this, we need to update the state from the path.
+	updateStateFromPath := &origin
+
+	for _, x := range s.equivalencies_ {
+		m := x.mov_
+
+		// x.mov_ is going to be the previous movement to reach state a: the same piece has to keep moving
+		if m.PieceId() == mov.PieceId() && !m.IsInverse(mov) && x.state_.ValidMovement(mov) {
+
+			// DO NOT MODIFY S, the prev state:
+			//a state has that path plus mov.
+			a.CopyMovChainAndAdd(x.path_, mov, updateStateFromPath)
+
+			// REPARENT: a should have a correct state because we've updated it from the origin!
+			a.SetPrevState(x.state_, mov)
+			return true
+		}
+	}
+	return false
+}
+
+// Movements of the shortest known path, built on demand
+func (s *seqState) PathChain() []defs.Command {
+	return s.movChain_.Slice()
+}
+
+// Pieces whose value tells alike pieces apart (grids.GridPiece2, grids.GridPiece3)
+type valuedPiece interface {
+	Id() int
+	Value() int
+	SetValue(int)
+}
+
+// Stores the piece values in the map of the state. Pieces in notAutoalikePieces keep their own id as value.
+func setPieceValues[P valuedPiece](s *seqState, pieces []P, notAutoalikePieces []int) {
+
+	// Edit not-autoalike piece values
+	for _, id := range notAutoalikePieces {
+		for _, q := range pieces {
+			if q.Id() == id {
+				q.SetValue(q.Id())
+				break
+			}
+		}
+	}
+
+	// Finally edit the map
+	for _, p := range pieces {
+		s.values().Set(p.Id(), p.Value())
+	}
+}
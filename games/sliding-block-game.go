@@ -12,23 +12,44 @@ type SBGame struct {
 	// Set of pieces
 	pieces []*grids.GridPiece2
 
+	// Pieces that can move: all but the fixed ones
+	movablePieces_ []*grids.GridPiece2
+
 	// Map of pieces by their id.
 	piecesById map[int]*grids.GridPiece2
 
-	// Groups of pieces that should be considered alike
-	alikePieces_ [][]int
+	// Fixed and alike pieces
+	pieceSettings
 
-	// If true, then alike pieces are automatically calculated
-	autoAlikePieces_ bool
+	// Representation of the states board
+	boardKind_ BoardKind
+}
 
-	// Set of pieces we want to maintain independent, not alike to other pieces
-	notAutoalikePieces_ []int
+// Representations of the board of the states. All of them give the same results; they differ in
+// speed and memory.
+type BoardKind int
+
+const (
+	AUTO_BOARD   BoardKind = iota // BIT_BOARD if the board fits, DENSE_BOARD otherwise
+	DENSE_BOARD                   // Every cell, as a matrix
+	SPARSE_BOARD                  // Piece positions and an occupancy bitboard: for large boards with few pieces
+	BIT_BOARD                     // Piece positions and bitmasks, for boards up to 64 cells
+)
+
+// Board kind by its name (auto, dense, sparse or bit)
+func BoardKindByName(name string) (BoardKind, bool) {
+	kinds := map[string]BoardKind{"auto": AUTO_BOARD, "dense": DENSE_BOARD, "sparse": SPARSE_BOARD, "bit": BIT_BOARD}
+	k, ok := kinds[name]
+	return k, ok
 }
 
 // Implements GameDef interface
 func (g *SBGame) Define(m *grids.Matrix2d) (err error) {
 	g.state_.Init(m)
 
+	g.pieceToValue_ = defs.NewPieceToValue()
+	g.state_.SetPieceToValue(g.pieceToValue_)
+
 	return nil
 }
 
@@ -46,20 +67,18 @@ func (g *SBGame) SetState(s defs.SeqGameState) (err error) {
 	return nil
 }
 
-// Alike pieces are pieces with the same shape. Two states with two of
-// these pieces at interchanged positions are considered the same state.
-// This function is used to mark which pieces are equivalent or alike.
-func (g *SBGame) AlikePieces(alikePieces [][]int) {
-	g.alikePieces_ = alikePieces
+// Shape of the cells, and steps of the pieces: square by default. Must be set before Build.
+func (g *SBGame) SetLattice(l grids.Lattice) {
+	g.state_.lattice_ = l
 }
 
-// Marks all pieces with same shape as equivalent
-func (g *SBGame) AutoAlikePieces() {
-	g.autoAlikePieces_ = true
+func (g *SBGame) Lattice() grids.Lattice {
+	return g.state_.lattice_
 }
 
-func (g *SBGame) SetNotAlikePiece(pieceId int) {
-	g.notAutoalikePieces_ = append(g.notAutoalikePieces_, pieceId)
+// Representation of the board, AUTO_BOARD by default. Must be set before Build.
+func (g *SBGame) SetBoardKind(k BoardKind) {
+	g.boardKind_ = k
 }
 
 func (g *SBGame) Build() (err error) {
@@ -77,6 +96,20 @@ func (g *SBGame) Build() (err error) {
 		g.piecesById[p.Id()] = p
 	}
 
+	g.movablePieces_ = nil
+	for _, p := range g.pieces {
+		if !g.IsFixed(p.Id()) {
+			g.movablePieces_ = append(g.movablePieces_, p)
+		}
+	}
+
+	switch {
+	case g.boardKind_ == SPARSE_BOARD:
+		g.state_.grid = grids.NewSparseBoard(g.state_.Grid(), g.pieces)
+	case g.boardKind_ == BIT_BOARD || g.boardKind_ == AUTO_BOARD && grids.FitsBitBoard(g.state_.Grid()):
+		g.state_.grid = grids.NewBitBoard(g.state_.Grid(), g.pieces)
+	}
+
 	return nil
 }
 
@@ -127,5 +160,12 @@ func (g *SBGame) State() (s defs.SeqGameState) {
 
 // Return a list of valid movements that can be done from this state
 func (g *SBGame) ValidMovementsBFS(pieceTrajectory []defs.Command) []defs.Command {
-	return g.state_.ValidMovementsBFS(g.pieces, pieceTrajectory)
+	return g.state_.ValidMovementsBFS(g.movablePieces_, pieceTrajectory)
+}
+
+// Return the whole piece moves that can be done from this state: one for each position a piece can
+// reach through a chain of steps, with the other pieces in their places. Each one is a
+// grids.CompoundMov2 holding the shortest chain of steps.
+func (g *SBGame) CompoundMovements() []defs.Command {
+	return g.state_.CompoundMovements(g.movablePieces_)
 }
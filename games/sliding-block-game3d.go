@@ -0,0 +1,99 @@
+package games
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// 3D Sliding Block Game type: polycubes on a voxel grid, moving one cell in any of the six directions.
+// Plays like SBGame, with the same fixed and alike pieces settings, so the same finders solve it.
+type SB3Game struct {
+
+	// Sliding Block game state
+	state_ SB3State
+
+	// Set of pieces
+	pieces []*grids.GridPiece3
+
+	// Pieces that can move: all but the fixed ones
+	movablePieces_ []*grids.GridPiece3
+
+	// Map of pieces by their id.
+	piecesById map[int]*grids.GridPiece3
+
+	// Fixed and alike pieces
+	pieceSettings
+}
+
+func (g *SB3Game) Define(m *grids.Matrix3d) (err error) {
+	g.state_.Init(m)
+
+	g.pieceToValue_ = defs.NewPieceToValue()
+	g.state_.SetPieceToValue(g.pieceToValue_)
+
+	return nil
+}
+
+func (g *SB3Game) SetState(s defs.SeqGameState) (err error) {
+	b, ok := s.(*SB3State)
+	if !ok {
+		panic("[SB3Game::SetState] defs.SeqGameState not of type SB3State!")
+	}
+
+	g.state_.CopyGrid(*b)
+	g.state_.UpdatePiecePositions(g.piecesById)
+	g.state_.SetPrevState(s.PrevState(), s.PrevMov())
+	return nil
+}
+
+func (g *SB3Game) Build() (err error) {
+
+	// Create the pieces
+	g.state_.BuildPieces(&g.pieces, g.alikePieces_, g.notAutoalikePieces_)
+
+	// If autoAlikePieces, then detect equivalent pieces
+	if g.autoAlikePieces_ {
+		g.state_.DetectAlikePieces(g.pieces, g.notAutoalikePieces_)
+	}
+
+	g.piecesById = make(map[int]*grids.GridPiece3)
+	g.movablePieces_ = nil
+	for _, p := range g.pieces {
+		g.piecesById[p.Id()] = p
+		if !g.IsFixed(p.Id()) {
+			g.movablePieces_ = append(g.movablePieces_, p)
+		}
+	}
+
+	return nil
+}
+
+// Playable interface
+func (g *SB3Game) Move(mov defs.Command) (err error) {
+	piece := g.piecesById[mov.PieceId()]
+
+	g.state_.ClearPiece(piece)
+	piece.Move(mov)
+	g.state_.PlacePiece(piece)
+
+	return nil
+}
+
+// Reverts the movement
+func (g *SB3Game) UndoMove(mov defs.Command) (err error) {
+	piece := g.piecesById[mov.PieceId()]
+
+	g.state_.ClearPiece(piece)
+	piece.Move(mov.Inverted())
+	g.state_.PlacePiece(piece)
+
+	return nil
+}
+
+// Return a copy of the state
+func (g *SB3Game) State() (s defs.SeqGameState) {
+	return g.state_.Clone()
+}
+
+// Return a list of valid movements that can be done from this state
+func (g *SB3Game) ValidMovementsBFS(pieceTrajectory []defs.Command) []defs.Command {
+	return g.state_.ValidMovementsBFS(g.movablePieces_, pieceTrajectory)
+}
@@ -0,0 +1,62 @@
+package games
+
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+func TestSB3Game(t *testing.T) {
+
+	// Two alike dominoes along the rows, and a unit cube
+	board := grids.Matrix3d{
+		{
+			{1, 0},
+			{1, 0},
+		},
+		{
+			{0, 2},
+			{3, 2},
+		},
+	}
+	swapped := grids.Matrix3d{
+		{
+			{2, 0},
+			{2, 0},
+		},
+		{
+			{0, 1},
+			{3, 1},
+		},
+	}
+
+	var g SB3Game
+	g.Define(&board)
+	g.AutoAlikePieces()
+	g.Build()
+
+	var s SB3State
+	s.Init(&swapped)
+	if v := g.pieceToValue_; v.At(1) != v.At(2) || v.At(1) == v.At(3) {
+		t.Errorf("Alike dominoes: values %d, %d, %d", v.At(1), v.At(2), v.At(3))
+	}
+	if !g.State().Equal(&s) || g.State().ToHash() != (&SB3State{seqState: seqState{pieceToValue_: g.pieceToValue_}, grid: &swapped}).ToHash() {
+		t.Errorf("Swapped alike dominoes should give an equal state")
+	}
+
+	// Domino 1 can only go right, 2 to the previous layer, and the cube 3 up
+	movs := g.ValidMovementsBFS(nil)
+	if len(movs) != 3 {
+		t.Fatalf("%d movements, should be 3", len(movs))
+	}
+
+	start := g.State()
+	for _, m := range movs {
+		g.Move(m)
+		if !g.State().ValidMovement(m.Inverted().(*grids.GridMov3)) {
+			t.Errorf("Movement cannot be undone")
+		}
+		g.UndoMove(m)
+		if !g.State().(*SB3State).Grid().Identical(start.(*SB3State).Grid()) {
+			t.Errorf("Undoing a movement should restore the state")
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package games
+
+import "math/rand"
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Two games built in the same process keep their own alike pieces.
+func TestIndependentAlikePieces(t *testing.T) {
+	board := grids.Matrix2d{
+		[]int{1, 0},
+		[]int{0, 2},
+	}
+	swapped := grids.Matrix2d{
+		[]int{2, 0},
+		[]int{0, 1},
+	}
+
+	var alike, distinct SBGame
+
+	alike.Define(&board)
+	alike.AutoAlikePieces()
+	alike.Build()
+
+	distinct.Define(&board)
+	distinct.Build()
+
+	var s SBPState
+	s.Init(&swapped)
+
+	if !alike.State().Equal(&s) {
+		t.Errorf("Swapped alike pieces should give an equal state")
+	}
+	if distinct.State().Equal(&s) {
+		t.Errorf("Swapped distinct pieces should give a different state")
+	}
+
+	// Building the second game must not change the first one
+	if alike.State().ToHash() != (&SBPState{seqState: seqState{pieceToValue_: alike.pieceToValue_}, grid: &swapped}).ToHash() {
+		t.Errorf("Alike pieces should have the same hash")
+	}
+}
+
+// Whole piece moves reach every free position, around the other pieces.
+func TestCompoundMovements(t *testing.T) {
+	var g SBGame
+	g.Define(&grids.Matrix2d{
+		[]int{1, 0, 0},
+		[]int{2, 3, 0},
+		[]int{0, 3, 0},
+	})
+	g.SetFixedPiece(3)
+	g.Build()
+
+	// Piece 1 reaches the 3 cells of the top row and right column, piece 2 the bottom left corner
+	reached := make(map[int][][2]int)
+	for _, m := range g.CompoundMovements() {
+		dRow, dCol := m.(*grids.CompoundMov2).Translation()
+		reached[m.PieceId()] = append(reached[m.PieceId()], [2]int{dRow, dCol})
+	}
+	if len(reached[1]) != 4 || len(reached[2]) != 1 || len(reached[3]) != 0 {
+		t.Fatalf("Unexpected moves: %v", reached)
+	}
+
+	// Shortest chain of steps to the bottom right corner
+	var far *grids.CompoundMov2
+	for _, m := range g.CompoundMovements() {
+		if dRow, dCol := m.(*grids.CompoundMov2).Translation(); m.PieceId() == 1 && dRow == 2 && dCol == 2 {
+			far = m.(*grids.CompoundMov2)
+		}
+	}
+	if far == nil || len(far.Steps()) != 4 {
+		t.Fatalf("Bottom right corner not reached in 4 steps: %v", far)
+	}
+
+	// Doing the move, or its steps, gives the same state; the inverse undoes it
+	start := g.State()
+	g.Move(far)
+	moved := g.State()
+	g.UndoMove(far)
+	if !g.State().Equal(start) {
+		t.Errorf("Compound move not undone")
+	}
+	for _, s := range far.Steps() {
+		g.Move(s)
+	}
+	if !g.State().Equal(moved) || moved.(*SBPState).Grid().At(2, 2) != 1 {
+		t.Errorf("Steps and compound move reach different states")
+	}
+	g.Move(far.Inverted().(*grids.CompoundMov2))
+	if !g.State().Equal(start) || !far.IsInverse(far.Inverted()) {
+		t.Errorf("Inverted move does not return to the start")
+	}
+}
+
+// Super Century start
+func newBoardKindGame(kind BoardKind) *SBGame {
+	var g SBGame
+	g.Define(&grids.Matrix2d{
+		[]int{2, 8, 9, 10},
+		[]int{2, 4, 1, 1},
+		[]int{3, 4, 1, 1},
+		[]int{3, 5, 5, 7},
+		[]int{0, 0, 6, 6},
+	})
+	g.AutoAlikePieces()
+	g.SetBoardKind(kind)
+	g.Build()
+	return &g
+}
+
+// Every kind of board gives the same movements, grids and hashes along a random walk.
+func TestBoardKinds(t *testing.T) {
+	kinds := []BoardKind{DENSE_BOARD, SPARSE_BOARD, BIT_BOARD, AUTO_BOARD}
+	var boards []*SBGame
+	for _, kind := range kinds {
+		boards = append(boards, newBoardKindGame(kind))
+	}
+	if _, ok := boards[3].state_.grid.(*grids.BitBoard); !ok {
+		t.Fatalf("A 4x5 board should be a bitboard by default")
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for step := 0; step < 500; step++ {
+		movs := boards[0].ValidMovementsBFS(nil)
+		states := []defs.SeqGameState{boards[0].State()}
+		for i, g := range boards[1:] {
+			other := g.ValidMovementsBFS(nil)
+			if len(other) != len(movs) {
+				t.Fatalf("Step %d, board kind %d: %d movements, dense has %d", step, kinds[i+1], len(other), len(movs))
+			}
+			for j := range movs {
+				dRow, dCol := movs[j].(*grids.GridMov2).Translation()
+				oRow, oCol := other[j].(*grids.GridMov2).Translation()
+				if movs[j].PieceId() != other[j].PieceId() || dRow != oRow || dCol != oCol {
+					t.Fatalf("Step %d, board kind %d: movement %d differs", step, kinds[i+1], j)
+				}
+			}
+
+			s := g.State()
+			if !s.(*SBPState).Grid().Identical(*states[0].(*SBPState).Grid()) || s.ToHash() != states[0].ToHash() {
+				t.Fatalf("Step %d, board kind %d: different grid or hash", step, kinds[i+1])
+			}
+			if !s.Equal(states[0]) || !states[0].Equal(s) {
+				t.Fatalf("Step %d, board kind %d: states not equal", step, kinds[i+1])
+			}
+			for id := 1; id <= 10; id++ {
+				for _, sym := range []grids.Symmetry{grids.IDENTITY, grids.ROTATE_180} {
+					if s.(*SBPState).FirstCell(id, sym) != states[0].(*SBPState).FirstCell(id, sym) {
+						t.Fatalf("Step %d, board kind %d: different first cell of piece %d", step, kinds[i+1], id)
+					}
+				}
+			}
+			states = append(states, s)
+		}
+
+		mov := movs[rnd.Intn(len(movs))]
+		for _, g := range boards {
+			g.Move(mov)
+		}
+	}
+}
+
+// Movements and state copies of a search step, on each kind of board
+func BenchmarkBoardKinds(b *testing.B) {
+	for _, kind := range []BoardKind{DENSE_BOARD, SPARSE_BOARD, BIT_BOARD} {
+		name := map[BoardKind]string{DENSE_BOARD: "dense", SPARSE_BOARD: "sparse", BIT_BOARD: "bit"}[kind]
+		b.Run(name, func(b *testing.B) {
+			g := newBoardKindGame(kind)
+			rnd := rand.New(rand.NewSource(1))
+			for i := 0; i < b.N; i++ {
+				movs := g.ValidMovementsBFS(nil)
+				for _, m := range movs {
+					g.Move(m)
+					g.State().ToHash()
+					g.UndoMove(m)
+				}
+				g.Move(movs[rnd.Intn(len(movs))])
+			}
+		})
+	}
+}
+
+// Hex and triangle lattices have 6 steps, and their own whole piece moves.
+func TestLattices(t *testing.T) {
+	newGame := func(l grids.Lattice, board grids.Matrix2d) *SBGame {
+		var g SBGame
+		g.SetLattice(l)
+		g.Define(&board)
+		g.Build()
+		return &g
+	}
+
+	// Up, right, and the hex up-right diagonal are one step
+	hex := newGame(grids.HEX_LATTICE, grids.Matrix2d{
+		[]int{0, 0},
+		[]int{1, 0},
+	})
+	if n := len(hex.ValidMovementsBFS(nil)); n != 3 {
+		t.Errorf("Hex: %d steps, should be 3", n)
+	}
+	for _, m := range hex.CompoundMovements() {
+		if dRow, dCol := m.(*grids.CompoundMov2).Translation(); len(m.(*grids.CompoundMov2).Steps()) != 1 {
+			t.Errorf("Hex: move (%d, %d) should be one step", dRow, dCol)
+		}
+	}
+
+	// A triangle only reaches the cells pointing its way
+	tri := newGame(grids.TRIANGLE_LATTICE, grids.Matrix2d{
+		[]int{1, 0, 0, 0},
+		[]int{0, 0, 0, 0},
+	})
+	reached := make(map[[2]int]bool)
+	for _, m := range tri.CompoundMovements() {
+		dRow, dCol := m.(*grids.CompoundMov2).Translation()
+		if (dRow+dCol)%2 != 0 {
+			t.Errorf("Triangle: move (%d, %d) turns the piece", dRow, dCol)
+		}
+		reached[[2]int{dRow, dCol}] = true
+	}
+	if len(reached) != 3 || !reached[[2]int{1, 1}] || !reached[[2]int{1, 3}] || !reached[[2]int{0, 2}] {
+		t.Errorf("Triangle: reached %v", reached)
+	}
+
+	// Triangles pointing different ways are never alike
+	var alike SBGame
+	alike.SetLattice(grids.TRIANGLE_LATTICE)
+	alike.Define(&grids.Matrix2d{
+		[]int{1, 2, 3, 0},
+	})
+	alike.AutoAlikePieces()
+	alike.Build()
+	if v := alike.pieceToValue_; v.At(1) != v.At(3) || v.At(1) == v.At(2) {
+		t.Errorf("Triangle alike pieces: values %d, %d, %d", v.At(1), v.At(2), v.At(3))
+	}
+
+	if len(hex.Symmetries()) != 0 || len(tri.Symmetries()) != 0 {
+		t.Errorf("Only square lattices have symmetries")
+	}
+}
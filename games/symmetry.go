@@ -0,0 +1,127 @@
+package games
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Board symmetries that keep the shape of every piece and leave the current state unchanged, alike
+// pieces being interchangeable. They take each state to a state as far from the current one, so
+// searches starting there may keep only one of them (see finder.SbpBfsFinder.SetSymmetry). Only square
+// lattices have them.
+func (g *SBGame) Symmetries() []grids.Symmetry {
+	var syms []grids.Symmetry
+	if g.Lattice() != grids.SQUARE_LATTICE {
+		return syms
+	}
+	for _, sym := range grids.SYMMETRIES {
+		ok := g.state_.EqualUnder(&g.state_, sym)
+		for _, p := range g.pieces {
+			if !ok {
+				break
+			}
+			ok = p.SymmetricUnder(sym)
+		}
+		if ok {
+			syms = append(syms, sym)
+		}
+	}
+	return syms
+}
+
+// True if the image of c by the symmetry equals the state
+func (s *SBPState) EqualUnder(c defs.SeqGameState, sym grids.Symmetry) bool {
+	s2, ok := c.(*SBPState)
+	if !ok {
+		panic("[SBPState::EqualUnder] state is not a SBPState!")
+	}
+
+	m := s.grid.Matrix()
+	m2 := s2.grid.Matrix()
+	rows := m.Rows()
+	cols := m.Cols()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			r, c := sym.Cell(rows, cols, i, j)
+			if s.value((*m)[r][c]) != s.value((*m2)[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Smallest hash of the state and its images by the symmetries: the same for all of them
+func (s *SBPState) CanonicalHash(syms []grids.Symmetry) int {
+	hash := s.ToHash()
+	for _, sym := range syms {
+		if h := s.symmetricHash(sym); h < hash {
+			hash = h
+		}
+	}
+	return hash
+}
+
+// Hash of the image of the state by the symmetry (see ToHash)
+func (s *SBPState) symmetricHash(sym grids.Symmetry) int {
+	m := s.grid.Matrix()
+	rows := m.Rows()
+	cols := m.Cols()
+
+	hash := 0
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			r, c := sym.Cell(rows, cols, i, j)
+			hash += (r + 1) * (rows*cols + c) * s.value((*m)[i][j])
+		}
+	}
+	return hash
+}
+
+// Image of the state by the symmetry, at the same depth. It has no path.
+func (s *SBPState) Transformed(sym grids.Symmetry) *SBPState {
+	c := s.Clone().(*SBPState)
+	image := s.grid.Matrix().Transformed(sym)
+	c.grid = s.grid.BoardFrom(&image)
+	c.depth_ = s.depth_
+	return c
+}
+
+// Image by the symmetry of a path from the start state, which the symmetry must leave unchanged (see
+// SBGame.Symmetries). Pieces are identified by their position, so alike pieces may exchange their ids.
+func TransformPath(start *grids.Matrix2d, path []defs.Command, sym grids.Symmetry) []defs.Command {
+	var orig, image grids.Matrix2d
+	orig.Copy(start)
+	image.Copy(start)
+
+	rows := start.Rows()
+	cols := start.Cols()
+
+	res := make([]defs.Command, 0, len(path))
+	for _, mov := range path {
+		gMov, ok := mov.(*grids.GridMov2)
+		if !ok {
+			panic("[games::TransformPath] mov is not a GridMov2!")
+		}
+
+		row, col := pieceCell(&orig, mov.PieceId())
+		r, c := sym.Cell(rows, cols, row, col)
+		dRow, dCol := sym.Translation(gMov.Translation())
+		m := grids.NewGridMov2(image.At(r, c), dRow, dCol)
+
+		orig.ApplyRawTranslation(mov.PieceId(), *gMov)
+		image.ApplyRawTranslation(m.PieceId(), *m)
+		res = append(res, m)
+	}
+	return res
+}
+
+// A cell of the piece
+func pieceCell(m *grids.Matrix2d, pieceId int) (int, int) {
+	for r, row := range *m {
+		for c, id := range row {
+			if id == pieceId {
+				return r, c
+			}
+		}
+	}
+	panic("[games::pieceCell] piece not found!")
+}
@@ -0,0 +1,78 @@
+package games
+
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+func TestSymmetries(t *testing.T) {
+	build := func(m grids.Matrix2d) *SBGame {
+		var g SBGame
+		g.Define(&m)
+		g.AutoAlikePieces()
+		g.Build()
+		return &g
+	}
+
+	cases := []struct {
+		name  string
+		board grids.Matrix2d
+		syms  int
+	}{
+		// Alike pieces 2 and 3 exchange their places
+		{"mirror", grids.Matrix2d{
+			[]int{2, 1, 1, 3},
+			[]int{2, 0, 0, 3},
+		}, 1},
+		{"all", grids.Matrix2d{
+			[]int{1, 0, 2},
+			[]int{0, 0, 0},
+			[]int{3, 0, 4},
+		}, 3},
+		{"asymmetric state", grids.Matrix2d{
+			[]int{1, 1, 0},
+			[]int{2, 0, 0},
+		}, 0},
+
+		// The L piece shape changes
+		{"asymmetric piece", grids.Matrix2d{
+			[]int{1, 0, 2},
+			[]int{1, 1, 2},
+		}, 0},
+	}
+	for _, c := range cases {
+		if syms := build(c.board).Symmetries(); len(syms) != c.syms {
+			t.Errorf("%s: symmetries %v", c.name, syms)
+		}
+	}
+
+	// The image of a path reaches the image of its final state
+	start := grids.Matrix2d{
+		[]int{2, 1, 1, 3},
+		[]int{2, 0, 0, 3},
+		[]int{0, 0, 0, 0},
+	}
+	g := build(start)
+	path := []defs.Command{grids.NewGridMov2(2, 1, 0), grids.NewGridMov2(1, 0, -1)}
+	image := TransformPath(&start, path, grids.MIRROR_LR)
+	if dRow, dCol := image[1].(*grids.GridMov2).Translation(); image[0].PieceId() != 3 || dRow != 0 || dCol != 1 {
+		t.Errorf("Unexpected image: %v", image)
+	}
+
+	for _, m := range path {
+		g.Move(m)
+	}
+	s := g.State().(*SBPState)
+	g = build(start)
+	for _, m := range image {
+		g.Move(m)
+	}
+	mirror := g.State().(*SBPState)
+
+	if s.Equal(mirror) || !s.EqualUnder(mirror, grids.MIRROR_LR) || !s.Transformed(grids.MIRROR_LR).Equal(mirror) {
+		t.Errorf("Image path reaches %v from %v", *mirror.Grid(), *s.Grid())
+	}
+	if s.CanonicalHash(grids.SYMMETRIES) != mirror.CanonicalHash(grids.SYMMETRIES) {
+		t.Errorf("Different canonical hashes")
+	}
+}
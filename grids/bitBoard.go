@@ -0,0 +1,213 @@
+package grids
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Biggest board a BitBoard holds
+const BITBOARD_MAX_CELLS = 64
+
+// Board of up to 64 cells, storing the position of each piece and the occupied cells as a bitmask. Cell
+// (r, c) is the bit r*cols+c. Checking and doing a move are a few shifts and masks.
+type BitBoard struct {
+	layout_ *bitLayout
+
+	// Position (boundary box) of each piece, by slot
+	pos_      []Coords2
+	occupied_ uint64
+}
+
+// Piece shapes as bitmasks, shared by all the boards of a game
+type bitLayout struct {
+	pieces_ *pieceLayout
+
+	// Cells of each piece at position (0, 0), and its height and width
+	masks_ []uint64
+	size_  []Coords2
+}
+
+// True if the board fits in a BitBoard
+func FitsBitBoard(m *Matrix2d) bool {
+	return m.Rows()*m.Cols() <= BITBOARD_MAX_CELLS
+}
+
+// Bitboard with the cells of m, holding the pieces (built from m, see SBGame.Build)
+func NewBitBoard(m *Matrix2d, pieces []*GridPiece2) *BitBoard {
+	if !FitsBitBoard(m) {
+		panic("[BitBoard::NewBitBoard] board too big!")
+	}
+
+	l := &bitLayout{pieces_: newPieceLayout(m, pieces)}
+	for _, cells := range l.pieces_.cells_ {
+		var mask uint64
+		var size Coords2
+		for _, cell := range cells {
+			mask |= 1 << uint(cell[0]*m.Cols()+cell[1])
+			if cell[0] >= size[0] {
+				size[0] = cell[0] + 1
+			}
+			if cell[1] >= size[1] {
+				size[1] = cell[1] + 1
+			}
+		}
+		l.masks_ = append(l.masks_, mask)
+		l.size_ = append(l.size_, size)
+	}
+	return l.board(m)
+}
+
+func (l *bitLayout) board(m *Matrix2d) *BitBoard {
+	b := &BitBoard{layout_: l, pos_: l.pieces_.positions(m)}
+	for s := range b.pos_ {
+		b.occupied_ |= b.mask(s, b.pos_[s])
+	}
+	return b
+}
+
+func (b *BitBoard) Rows() int {
+	return b.layout_.pieces_.rows_
+}
+
+func (b *BitBoard) Cols() int {
+	return b.layout_.pieces_.cols_
+}
+
+func (b *BitBoard) At(row int, col int) int {
+	bit := uint64(1) << uint(row*b.Cols()+col)
+	if b.occupied_&bit == 0 {
+		return 0
+	}
+	for s, pos := range b.pos_ {
+		if b.mask(s, pos)&bit != 0 {
+			return b.layout_.pieces_.ids_[s]
+		}
+	}
+	return 0
+}
+
+// Builds a new matrix on each call
+func (b *BitBoard) Matrix() *Matrix2d {
+	return b.layout_.pieces_.matrix(b.pos_)
+}
+
+func (b *BitBoard) CloneBoard() Board {
+	c := &BitBoard{layout_: b.layout_, pos_: make([]Coords2, len(b.pos_)), occupied_: b.occupied_}
+	copy(c.pos_, b.pos_)
+	return c
+}
+
+func (b *BitBoard) BoardFrom(m *Matrix2d) Board {
+	return b.layout_.board(m)
+}
+
+// Boards are equal if each piece value is at the same positions
+func (b *BitBoard) SameValues(o Board, ptv *defs.PieceToValue) bool {
+	ob, ok := o.(*BitBoard)
+	if !ok {
+		return b.Matrix().SameValues(o, ptv)
+	}
+	if b.occupied_ != ob.occupied_ {
+		return false
+	}
+	return b.layout_.pieces_.sameKeys(b.pos_, ob.pos_, ptv)
+}
+
+// False if t has no piece
+func (b *BitBoard) SubValues(t Board, ptv *defs.PieceToValue) bool {
+	return subValues(b, t, ptv)
+}
+
+func (b *BitBoard) HashValues(ptv *defs.PieceToValue) int {
+	return b.layout_.pieces_.hash(b.pos_, ptv)
+}
+
+// Returns array of valid movements for this piece
+func (b *BitBoard) PieceMovements(p *GridPiece2) (movs []*GridMov2) {
+	s := b.layout_.pieces_.slot(p.Id())
+	if b.canMove(s, 1, 0) {
+		movs = append(movs, &GridMov2{p.Id(), 1, 0})
+	}
+	if b.canMove(s, -1, 0) {
+		movs = append(movs, &GridMov2{p.Id(), -1, 0})
+	}
+	if b.canMove(s, 0, 1) {
+		movs = append(movs, &GridMov2{p.Id(), 0, 1})
+	}
+	if b.canMove(s, 0, -1) {
+		movs = append(movs, &GridMov2{p.Id(), 0, -1})
+	}
+	return movs
+}
+
+func (b *BitBoard) CanPieceMove(p *GridPiece2, dRow int, dCol int) bool {
+	return b.canMove(b.layout_.pieces_.slot(p.Id()), dRow, dCol)
+}
+
+func (b *BitBoard) ValidMove(mov GridMov2) bool {
+	return b.canMove(b.layout_.pieces_.slot(mov.PieceId()), mov.dRow, mov.dCol)
+}
+
+func (b *BitBoard) PieceReachableMovs(p *GridPiece2) []*CompoundMov2 {
+	return reachableMovs(b, p, SQUARE_LATTICE)
+}
+
+// True if the piece of the slot can be translated: it stays on the board, and the cells it would cover
+// are free or its own
+func (b *BitBoard) canMove(s int, dRow int, dCol int) bool {
+	pos := b.pos_[s]
+	to := Coords2{pos[0] + dRow, pos[1] + dCol}
+	if !b.inside(s, to) {
+		return false
+	}
+	return (b.occupied_&^b.mask(s, pos))&b.mask(s, to) == 0
+}
+
+// Removes the piece, which must be at its position
+func (b *BitBoard) ClearPiece(p *GridPiece2) {
+	s := b.layout_.pieces_.slot(p.Id())
+	if b.pos_[s] != p.position_ {
+		panic("[BitBoard::ClearPiece] piece not at its position!")
+	}
+	b.occupied_ &^= b.mask(s, b.pos_[s])
+}
+
+// Puts the piece at its position, on free cells
+func (b *BitBoard) PlacePiece(p *GridPiece2) {
+	b.place(b.layout_.pieces_.slot(p.Id()), p.position_)
+}
+
+func (b *BitBoard) ApplyRawTranslation(pieceId int, mov GridMov2) {
+	s := b.layout_.pieces_.slot(pieceId)
+	b.occupied_ &^= b.mask(s, b.pos_[s])
+	b.place(s, Coords2{b.pos_[s][0] + mov.dRow, b.pos_[s][1] + mov.dCol})
+}
+
+func (b *BitBoard) UpdatePiecePositions(piecesById map[int]*GridPiece2) {
+	for s, id := range b.layout_.pieces_.ids_ {
+		if p, ok := piecesById[id]; ok {
+			p.SetPosition(b.pos_[s][0], b.pos_[s][1])
+		}
+	}
+}
+
+func (b *BitBoard) PieceCells(pieceId int) []Coords2 {
+	s := b.layout_.pieces_.slot(pieceId)
+	return b.layout_.pieces_.pieceCells(s, b.pos_[s])
+}
+
+func (b *BitBoard) place(s int, pos Coords2) {
+	if !b.inside(s, pos) || b.occupied_&b.mask(s, pos) != 0 {
+		panic("[BitBoard::place] invalid movement!")
+	}
+	b.occupied_ |= b.mask(s, pos)
+	b.pos_[s] = pos
+}
+
+// True if the piece of the slot fits in the board at the position
+func (b *BitBoard) inside(s int, pos Coords2) bool {
+	size := b.layout_.size_[s]
+	return pos[0] >= 0 && pos[1] >= 0 && pos[0]+size[0] <= b.Rows() && pos[1]+size[1] <= b.Cols()
+}
+
+// Cells of the piece of the slot at the position, which must be inside the board
+func (b *BitBoard) mask(s int, pos Coords2) uint64 {
+	return b.layout_.masks_[s] << uint(pos[0]*b.Cols()+pos[1])
+}
@@ -0,0 +1,280 @@
+package grids
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Cells of a sliding blocks puzzle board and the pieces on them. Matrix2d is the dense board, storing
+// every cell; other boards store less, but read the same cells. Pieces are compared by value (see
+// defs.PieceToValue), so alike pieces are interchangeable.
+type Board interface {
+	Rows() int
+	Cols() int
+
+	// Id of the piece covering the cell, 0 if empty
+	At(row int, col int) int
+
+	// The cells as a matrix. Must not be modified.
+	Matrix() *Matrix2d
+
+	// Copy of the board, and new board of the same kind with the cells of m
+	CloneBoard() Board
+	BoardFrom(m *Matrix2d) Board
+
+	// Same values in every cell, in the non-zero cells of t, and hash of the values
+	SameValues(b Board, ptv *defs.PieceToValue) bool
+	SubValues(t Board, ptv *defs.PieceToValue) bool
+	HashValues(ptv *defs.PieceToValue) int
+
+	// Movements, in the same order for every kind of board
+	PieceMovements(p *GridPiece2) []*GridMov2
+	CanPieceMove(p *GridPiece2, dRow int, dCol int) bool
+	ValidMove(mov GridMov2) bool
+	PieceReachableMovs(p *GridPiece2) []*CompoundMov2
+
+	// Pieces placed by the game, at their current position
+	ClearPiece(p *GridPiece2)
+	PlacePiece(p *GridPiece2)
+	ApplyRawTranslation(pieceId int, mov GridMov2)
+
+	// Sets the positions of the pieces from the board
+	UpdatePiecePositions(piecesById map[int]*GridPiece2)
+
+	// Cells covered by the piece. Boards storing piece positions find them in time proportional to the
+	// piece size.
+	PieceCells(pieceId int) []Coords2
+}
+
+func (g *Matrix2d) Matrix() *Matrix2d {
+	return g
+}
+
+func (g *Matrix2d) CloneBoard() Board {
+	var c Matrix2d
+	c.Copy(g)
+	return &c
+}
+
+func (g *Matrix2d) BoardFrom(m *Matrix2d) Board {
+	return m.CloneBoard()
+}
+
+func (g *Matrix2d) SameValues(b Board, ptv *defs.PieceToValue) bool {
+	m := b.Matrix()
+	rows := g.Rows()
+	cols := g.Cols()
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if ptv.At((*g)[i][j]) != ptv.At((*m)[i][j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// False if t has no piece
+func (g *Matrix2d) SubValues(t Board, ptv *defs.PieceToValue) bool {
+	m := t.Matrix()
+	rows := g.Rows()
+	cols := g.Cols()
+
+	anyPieceFound := false
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+
+			// Only check pieces from 't' board
+			x := ptv.At((*m)[i][j])
+			if x > 0 {
+				anyPieceFound = true
+				if ptv.At((*g)[i][j]) != x {
+					return false
+				}
+			}
+		}
+	}
+	return anyPieceFound
+}
+
+func (g *Matrix2d) HashValues(ptv *defs.PieceToValue) int {
+	rows := g.Rows()
+	cols := g.Cols()
+
+	hash := 0
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			hash += (i + 1) * (rows*cols + j) * ptv.At((*g)[i][j])
+		}
+	}
+	return hash
+}
+
+// Scans the whole matrix
+func (g *Matrix2d) PieceCells(pieceId int) (cells []Coords2) {
+	for r, row := range *g {
+		for c, id := range row {
+			if id == pieceId {
+				cells = append(cells, Coords2{r, c})
+			}
+		}
+	}
+	return cells
+}
+
+// Same as Matrix2d.SubValues, reading the cells of b one by one
+func subValues(b Board, t Board, ptv *defs.PieceToValue) bool {
+	m := t.Matrix()
+	anyPieceFound := false
+	for r, row := range *m {
+		for c, id := range row {
+			x := ptv.At(id)
+			if x > 0 {
+				anyPieceFound = true
+				if ptv.At(b.At(r, c)) != x {
+					return false
+				}
+			}
+		}
+	}
+	return anyPieceFound
+}
+
+// Board size and piece shapes, shared by all the boards of a game that store piece positions. Pieces
+// are numbered by slot, in the order given to the layout.
+type pieceLayout struct {
+	rows_ int
+	cols_ int
+
+	// Id, cells and top-left cell of the piece of each slot (see GridPiece2), and slot of each id
+	ids_   []int
+	cells_ [][]Coords2
+	tl_    []Coords2
+	slots_ map[int]int
+
+	// Sums over the cells of each piece, to hash it in constant time (see hash)
+	sums_ [][4]int
+}
+
+func newPieceLayout(m *Matrix2d, pieces []*GridPiece2) *pieceLayout {
+	l := &pieceLayout{rows_: m.Rows(), cols_: m.Cols(), slots_: make(map[int]int)}
+	for i, p := range pieces {
+		l.ids_ = append(l.ids_, p.Id())
+		l.cells_ = append(l.cells_, p.cells_)
+		l.tl_ = append(l.tl_, p.tl_)
+		l.slots_[p.Id()] = i
+
+		var sum [4]int
+		for _, cell := range p.cells_ {
+			sum[0]++
+			sum[1] += cell[0]
+			sum[2] += cell[1]
+			sum[3] += cell[0] * cell[1]
+		}
+		l.sums_ = append(l.sums_, sum)
+	}
+	return l
+}
+
+func (l *pieceLayout) slot(pieceId int) int {
+	s, ok := l.slots_[pieceId]
+	if !ok {
+		panic("[pieceLayout::slot] unknown piece!")
+	}
+	return s
+}
+
+// Position of each piece in m
+func (l *pieceLayout) positions(m *Matrix2d) []Coords2 {
+	pos := make([]Coords2, len(l.ids_))
+	found := make([]bool, len(l.ids_))
+	for r := 0; r < l.rows_; r++ {
+		for c := 0; c < l.cols_; c++ {
+			id := m.At(r, c)
+			if id == 0 {
+				continue
+			}
+			s := l.slot(id)
+			if !found[s] {
+				found[s] = true
+				pos[s] = Coords2{r - l.tl_[s][0], c - l.tl_[s][1]}
+			}
+		}
+	}
+	for _, ok := range found {
+		if !ok {
+			panic("[pieceLayout::positions] piece not found in the matrix!")
+		}
+	}
+	return pos
+}
+
+// Matrix with the pieces at the positions
+func (l *pieceLayout) matrix(pos []Coords2) *Matrix2d {
+	m := make(Matrix2d, l.rows_)
+	for r := range m {
+		m[r] = make([]int, l.cols_)
+	}
+	for s, p := range pos {
+		for _, cell := range l.cells_[s] {
+			m[p[0]+cell[0]][p[1]+cell[1]] = l.ids_[s]
+		}
+	}
+	return &m
+}
+
+// Cells of the piece of the slot, at the position
+func (l *pieceLayout) pieceCells(s int, pos Coords2) []Coords2 {
+	cells := make([]Coords2, len(l.cells_[s]))
+	for i, cell := range l.cells_[s] {
+		cells[i] = Coords2{pos[0] + cell[0], pos[1] + cell[1]}
+	}
+	return cells
+}
+
+// True if the piece of the slot, at the position, covers the cell
+func (l *pieceLayout) covers(s int, pos Coords2, row int, col int) bool {
+	for _, cell := range l.cells_[s] {
+		if pos[0]+cell[0] == row && pos[1]+cell[1] == col {
+			return true
+		}
+	}
+	return false
+}
+
+// Same hash as the dense board: each cell (r, c) adds (r+1)*(rows*cols+c)*value, and the cells of a
+// piece add value*(n*R*C + R*sum(c) + C*sum(r) + sum(r*c)), with (R, C) its position shifted
+func (l *pieceLayout) hash(pos []Coords2, ptv *defs.PieceToValue) int {
+	hash := 0
+	for s, p := range pos {
+		sum := l.sums_[s]
+		r := p[0] + 1
+		c := l.rows_*l.cols_ + p[1]
+		hash += ptv.At(l.ids_[s]) * (sum[0]*r*c + r*sum[2] + c*sum[1] + sum[3])
+	}
+	return hash
+}
+
+// True if each piece value is at the same positions in both boards
+func (l *pieceLayout) sameKeys(pos1 []Coords2, pos2 []Coords2, ptv *defs.PieceToValue) bool {
+	var buf1, buf2 [32]int
+	k1 := l.keys(pos1, ptv, buf1[:0])
+	k2 := l.keys(pos2, ptv, buf2[:0])
+	for i := range k1 {
+		if k1[i] != k2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Value and position of each piece as a single number, sorted and appended to buf
+func (l *pieceLayout) keys(pos []Coords2, ptv *defs.PieceToValue, buf []int) []int {
+	for s, p := range pos {
+		k := ptv.At(l.ids_[s])*l.rows_*l.cols_ + p[0]*l.cols_ + p[1]
+		i := len(buf)
+		buf = append(buf, k)
+		for ; i > 0 && buf[i-1] > k; i-- {
+			buf[i] = buf[i-1]
+		}
+		buf[i] = k
+	}
+	return buf
+}
@@ -0,0 +1,118 @@
+package grids
+
+import "fmt"
+
+// Move of a piece through a chain of one cell steps, a single move in the move metric. The piece ends
+// translated by the sum of the steps.
+type CompoundMov2 struct {
+	pieceId int
+	dRow    int
+	dCol    int
+	steps_  []*GridMov2
+}
+
+func NewCompoundMov2(pieceId int, steps []*GridMov2) *CompoundMov2 {
+	m := &CompoundMov2{pieceId: pieceId, steps_: steps}
+	for _, s := range steps {
+		m.dRow += s.dRow
+		m.dCol += s.dCol
+	}
+	return m
+}
+
+func (m *CompoundMov2) PieceId() int {
+	return m.pieceId
+}
+
+// Translation of the whole move
+func (m *CompoundMov2) Translation() (dRow int, dCol int) {
+	return m.dRow, m.dCol
+}
+
+// The one cell steps, in order
+func (m *CompoundMov2) Steps() []*GridMov2 {
+	return m.steps_
+}
+
+// The whole move as a single translation
+func (m *CompoundMov2) Mov() GridMov2 {
+	return GridMov2{m.pieceId, m.dRow, m.dCol}
+}
+
+// Same steps backwards
+func (m *CompoundMov2) Inverted() interface{} {
+	steps := make([]*GridMov2, len(m.steps_))
+	for i, s := range m.steps_ {
+		steps[len(steps)-1-i] = s.Inverted().(*GridMov2)
+	}
+	return NewCompoundMov2(m.pieceId, steps)
+}
+
+// True if x moves the same piece back to its place (x can be a GridMov2)
+func (m *CompoundMov2) IsInverse(x interface{}) bool {
+	switch y := x.(type) {
+	case *CompoundMov2:
+		return m.pieceId == y.pieceId && m.dRow+y.dRow == 0 && m.dCol+y.dCol == 0
+	case *GridMov2:
+		return m.pieceId == y.pieceId && m.dRow+y.dRow == 0 && m.dCol+y.dCol == 0
+	}
+	panic("[CompoundMov2::IsInverse] param is not a grid move!")
+}
+
+// True if x moves the same piece to the same place, whatever the steps
+func (m *CompoundMov2) Equals(x interface{}) bool {
+	y, ok := x.(*CompoundMov2)
+	return ok && m.pieceId == y.pieceId && m.dRow == y.dRow && m.dCol == y.dCol
+}
+
+// Prints the steps, as GridMov2 does
+func (m *CompoundMov2) Print() {
+	for i, s := range m.steps_ {
+		if i > 0 {
+			fmt.Print(",")
+		}
+		s.Print()
+	}
+}
+
+// Moves the piece can do, keeping the other pieces in their places: one for each position it reaches
+// through a chain of steps, with the shortest chain. Positions are found in breadth-first order, so the
+// closest ones come first.
+func (g *Matrix2d) PieceReachableMovs(p *GridPiece2) []*CompoundMov2 {
+	return reachableMovs(g, p, SQUARE_LATTICE)
+}
+
+// Reachable positions of the piece on any board and lattice (see Matrix2d.PieceReachableMovs)
+func reachableMovs(g Board, p *GridPiece2, l Lattice) (movs []*CompoundMov2) {
+	type position struct {
+		dRow, dCol int
+	}
+	var dirs []position
+	for _, s := range l.Steps() {
+		dirs = append(dirs, position{s[0], s[1]})
+	}
+
+	// Chain of steps reaching each position
+	reached := map[position][]*GridMov2{{0, 0}: nil}
+	queue := []position{{0, 0}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, d := range dirs {
+			next := position{cur.dRow + d.dRow, cur.dCol + d.dCol}
+			if _, ok := reached[next]; ok || !g.CanPieceMove(p, next.dRow, next.dCol) {
+				continue
+			}
+
+			steps := make([]*GridMov2, len(reached[cur]), len(reached[cur])+1)
+			copy(steps, reached[cur])
+			steps = append(steps, &GridMov2{p.Id(), d.dRow, d.dCol})
+
+			reached[next] = steps
+			queue = append(queue, next)
+			movs = append(movs, NewCompoundMov2(p.Id(), steps))
+		}
+	}
+	return movs
+}
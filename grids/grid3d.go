@@ -0,0 +1,300 @@
+package grids
+
+import "fmt"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Voxel grid: 0 are void cells, numbers represent polycubes. Each piece is represented by a unique number.
+// m[1][2][3] => layer 1, row 2, column 3. All layers have the same rows and columns.
+type Matrix3d [][][]int
+
+func (g *Matrix3d) Layers() int {
+	if g != nil {
+		return len(*g)
+	}
+	return 0
+}
+
+func (g *Matrix3d) Rows() int {
+	if g.Layers() > 0 {
+		return len((*g)[0])
+	}
+	return 0
+}
+
+func (g *Matrix3d) Cols() int {
+	if g.Rows() > 0 {
+		return len((*g)[0][0])
+	}
+	return 0
+}
+
+func (g *Matrix3d) At(layer int, row int, col int) int {
+	return (*g)[layer][row][col]
+}
+
+func (g *Matrix3d) SetAt(layer int, row int, col int, value int) {
+	(*g)[layer][row][col] = value
+}
+
+func (g *Matrix3d) Copy(m *Matrix3d) {
+	*g = make([][][]int, m.Layers())
+	for l, layer := range *m {
+		(*g)[l] = make([][]int, len(layer))
+		for r, row := range layer {
+			(*g)[l][r] = make([]int, len(row))
+			copy((*g)[l][r], row)
+		}
+	}
+}
+
+func (g *Matrix3d) Clone() *Matrix3d {
+	var c Matrix3d
+	c.Copy(g)
+	return &c
+}
+
+func (g *Matrix3d) Max() int {
+	max := 0
+	for _, layer := range *g {
+		for _, row := range layer {
+			for _, v := range row {
+				if max < v {
+					max = v
+				}
+			}
+		}
+	}
+	return max
+}
+
+func (g *Matrix3d) inside(layer int, row int, col int) bool {
+	return layer >= 0 && layer < g.Layers() && row >= 0 && row < g.Rows() && col >= 0 && col < g.Cols()
+}
+
+// Generates one GridPiece3 for each piece in the grid (see Matrix2d.GeneratePieces). Returns the number of
+// pieces.
+func (g *Matrix3d) GeneratePieces(pieces *[]*GridPiece3, alikePieces [][]int) int {
+	alikeValue := g.Max() + 1
+	alikeValuesMap := make(map[int]int)
+	for _, equivPieceIds := range alikePieces {
+		for _, id := range equivPieceIds {
+			alikeValuesMap[id] = alikeValue
+		}
+		alikeValue++
+	}
+
+	foundPieces := make(map[int]*GridPiece3)
+	for l, layer := range *g {
+		for r, row := range layer {
+			for c, v := range row {
+				if v == 0 {
+					continue
+				}
+				p := foundPieces[v]
+				if p == nil {
+					p = NewGridPiece3(v, alikeValuesMap[v])
+					*pieces = append(*pieces, p)
+					foundPieces[v] = p
+				}
+				p.AddCell(Coords3{l, r, c})
+			}
+		}
+	}
+
+	// Fix positions, making cells' origin the piece origin
+	for _, p := range *pieces {
+		p.FixPosition()
+	}
+	return len(*pieces)
+}
+
+// Returns array of valid movements for this piece, one for each of the six steps it can do
+func (g *Matrix3d) PieceMovements(p *GridPiece3) (movs []*GridMov3) {
+	for _, s := range s_voxelSteps_ {
+		if g.CanPieceMove(p, s[0], s[1], s[2]) {
+			movs = append(movs, &GridMov3{p.Id(), s[0], s[1], s[2]})
+		}
+	}
+	return movs
+}
+
+// True if the piece can be translated: its cells stay on the grid, on free cells or on its own cells
+func (g *Matrix3d) CanPieceMove(p *GridPiece3, dLayer int, dRow int, dCol int) bool {
+	for _, cell := range p.cells_ {
+		layer := p.position_[0] + cell[0] + dLayer
+		row := p.position_[1] + cell[1] + dRow
+		col := p.position_[2] + cell[2] + dCol
+
+		if !g.inside(layer, row, col) {
+			return false
+		}
+		if v := g.At(layer, row, col); v != 0 && v != p.Id() {
+			return false
+		}
+	}
+	return true
+}
+
+// Same as CanPieceMove, reading the piece from the grid
+func (g *Matrix3d) ValidMove(mov GridMov3) bool {
+	for l, layer := range *g {
+		for r, row := range layer {
+			for c, v := range row {
+				if v != mov.pieceId {
+					continue
+				}
+				if !g.inside(l+mov.dLayer, r+mov.dRow, c+mov.dCol) {
+					return false
+				}
+				if x := g.At(l+mov.dLayer, r+mov.dRow, c+mov.dCol); x != 0 && x != mov.pieceId {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Sets to 0 all piece cells into the grid
+func (g *Matrix3d) ClearPiece(p *GridPiece3) {
+	for _, cell := range p.cells_ {
+		layer := p.position_[0] + cell[0]
+		row := p.position_[1] + cell[1]
+		col := p.position_[2] + cell[2]
+
+		if !g.inside(layer, row, col) || g.At(layer, row, col) != p.Id() {
+			fmt.Printf("\n\n		*ERROR: <grid3d::ClearPiece> Piece %d not at (%d,%d,%d)\n", p.Id(), layer, row, col)
+			panic("<grid3d::ClearPiece> Invalid grid cell")
+		}
+		g.SetAt(layer, row, col, 0)
+	}
+}
+
+// Sets to piece.Id() all piece cells into the grid
+func (g *Matrix3d) PlacePiece(p *GridPiece3) {
+	for _, cell := range p.cells_ {
+		layer := p.position_[0] + cell[0]
+		row := p.position_[1] + cell[1]
+		col := p.position_[2] + cell[2]
+
+		if !g.inside(layer, row, col) || g.At(layer, row, col) != 0 {
+			fmt.Printf("\n\n		*ERROR: <grid3d::PlacePiece> Cannot place piece %d at (%d,%d,%d)\n", p.Id(), layer, row, col)
+			panic("<grid3d::PlacePiece> Invalid grid cell")
+		}
+		g.SetAt(layer, row, col, p.Id())
+	}
+}
+
+// Moves each piece cell found in the grid
+func (g *Matrix3d) ApplyRawTranslation(pieceId int, mov GridMov3) {
+	var cells []Coords3
+	for l, layer := range *g {
+		for r, row := range layer {
+			for c, v := range row {
+				if v == pieceId {
+					cells = append(cells, Coords3{l, r, c})
+					g.SetAt(l, r, c, 0)
+				}
+			}
+		}
+	}
+
+	for _, cell := range cells {
+		layer := cell[0] + mov.dLayer
+		row := cell[1] + mov.dRow
+		col := cell[2] + mov.dCol
+
+		if !g.inside(layer, row, col) || g.At(layer, row, col) != 0 {
+			fmt.Printf("\n\n		*ERROR::ApplyRawTranslation Cannot move (%v) piece %d to cell (%d,%d,%d)\n", mov, pieceId, layer, row, col)
+			panic("[grids::ApplyRawTranslation] invalid movement!")
+		}
+		g.SetAt(layer, row, col, pieceId)
+	}
+}
+
+// Reads the grid and positions each piece
+func (g *Matrix3d) UpdatePiecePositions(piecesById map[int]*GridPiece3) {
+	found := make(map[int]bool)
+	for l, layer := range *g {
+		for r, row := range layer {
+			for c, v := range row {
+				if v != 0 && !found[v] {
+					found[v] = true
+					piecesById[v].SetPositionTL(l, r, c)
+
+					if len(found) == len(piecesById) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// Same size and cells
+func (g *Matrix3d) Identical(m *Matrix3d) bool {
+	if g.Layers() != m.Layers() || g.Rows() != m.Rows() || g.Cols() != m.Cols() {
+		return false
+	}
+	for l, layer := range *g {
+		for r, row := range layer {
+			for c, v := range row {
+				if v != m.At(l, r, c) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// Each cell holds pieces of the same value
+func (g *Matrix3d) SameValues(m *Matrix3d, ptv *defs.PieceToValue) bool {
+	for l, layer := range *g {
+		for r, row := range layer {
+			for c, v := range row {
+				if ptv.At(v) != ptv.At(m.At(l, r, c)) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// The pieces of t are at the same cells (by value). False if t has no piece.
+func (g *Matrix3d) SubValues(t *Matrix3d, ptv *defs.PieceToValue) bool {
+	anyPieceFound := false
+	for l, layer := range *t {
+		for r, row := range layer {
+			for c, v := range row {
+				x := ptv.At(v)
+				if x > 0 {
+					anyPieceFound = true
+					if ptv.At(g.At(l, r, c)) != x {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return anyPieceFound
+}
+
+// Same as Matrix2d.HashValues, on the layers stacked as one matrix
+func (g *Matrix3d) HashValues(ptv *defs.PieceToValue) int {
+	rows := g.Layers() * g.Rows()
+	cols := g.Cols()
+
+	hash := 0
+	for l, layer := range *g {
+		for r, row := range layer {
+			i := l*g.Rows() + r
+			for j, v := range row {
+				hash += (i + 1) * (rows*cols + j) * ptv.At(v)
+			}
+		}
+	}
+	return hash
+}
@@ -0,0 +1,103 @@
+package grids
+
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+func TestMatrix3d(t *testing.T) {
+	m := Matrix3d{
+		{
+			{1, 1, 0},
+			{0, 2, 2},
+		},
+		{
+			{0, 1, 0},
+			{3, 0, 3},
+		},
+	}
+
+	var pieces []*GridPiece3
+	if n := m.GeneratePieces(&pieces, nil); n != 3 {
+		t.Fatalf("Matrix3d: %d pieces, should be 3", n)
+	}
+	p := pieces[0]
+	if p.Len() != 3 || p.Position() != (Coords3{0, 0, 0}) {
+		t.Errorf("Matrix3d: piece 1 has %d cells at %v", p.Len(), p.Position())
+	}
+
+	// Piece 2 can only go left, piece 1 only right
+	if movs := m.PieceMovements(pieces[1]); len(movs) != 1 || !movs[0].Equals(NewGridMov3(2, 0, 0, -1)) {
+		t.Errorf("Matrix3d: piece 2 has %d movements, should only move left", len(movs))
+	}
+	movs := m.PieceMovements(p)
+	if len(movs) != 1 || !movs[0].Equals(NewGridMov3(1, 0, 0, 1)) {
+		t.Errorf("Matrix3d: piece 1 has %d movements, should only move right", len(movs))
+	}
+
+	// Moving the piece and reading it back from the grid
+	m.ClearPiece(p)
+	p.Move(movs[0])
+	m.PlacePiece(p)
+	p.SetPosition(0, 0, 0)
+	m.UpdatePiecePositions(map[int]*GridPiece3{1: p})
+	if p.Position() != (Coords3{0, 0, 1}) || m.At(1, 0, 2) != 1 || m.At(0, 0, 0) != 0 {
+		t.Errorf("Matrix3d: piece 1 at %v after moving right", p.Position())
+	}
+
+	// Raw translations and validity on the grid give the same
+	c := m.Clone()
+	c.ApplyRawTranslation(1, *NewGridMov3(1, 0, 0, -1))
+	if !c.ValidMove(*NewGridMov3(1, 0, 0, 1)) || c.ValidMove(*NewGridMov3(1, 0, 1, 0)) {
+		t.Errorf("Matrix3d: wrong valid movements after a raw translation")
+	}
+	if c.Identical(&m) || c.HashValues(defs.NewPieceToValue()) == m.HashValues(defs.NewPieceToValue()) {
+		t.Errorf("Matrix3d: different grids should be different")
+	}
+}
+
+func TestAlikePolycubes(t *testing.T) {
+
+	// L-trominoes across the layers: 3 is turned
+	m := Matrix3d{
+		{
+			{1, 0, 2, 0},
+		},
+		{
+			{1, 1, 2, 3},
+		},
+		{
+			{0, 0, 3, 3},
+		},
+	}
+	m2 := Matrix3d{{{0, 0, 0, 0}}, {{0, 0, 0, 0}}, {{0, 0, 0, 0}}}
+	m2.SetAt(0, 0, 1, 4)
+	m2.SetAt(1, 0, 1, 4)
+	m2.SetAt(1, 0, 2, 4)
+
+	var pieces []*GridPiece3
+	m.GeneratePieces(&pieces, nil)
+	m2.GeneratePieces(&pieces, nil)
+
+	DetectAlikePieces3(pieces, 10)
+	values := make(map[int]int)
+	for _, p := range pieces {
+		values[p.Id()] = p.Value()
+	}
+	if values[1] != values[4] || values[1] == values[3] || values[1] == values[2] {
+		t.Errorf("Alike polycubes: values %v", values)
+	}
+}
+
+func TestTrajectoryTouchesWithMov3(t *testing.T) {
+
+	// Four steps around the start, one of them to the next layer: a step left ends next to the trajectory
+	path := []defs.Command{NewGridMov3(1, 0, 1, 0), NewGridMov3(1, 1, 0, 0), NewGridMov3(1, 0, 0, 1), NewGridMov3(1, 0, -1, 0)}
+	inverted := []defs.Command{path[3], path[2], path[1], path[0]}
+
+	if !TrajectoryTouchesWithMov3(inverted, NewGridMov3(1, 0, 0, -1)) {
+		t.Errorf("Moving left should touch the trajectory")
+	}
+	if TrajectoryTouchesWithMov3(inverted, NewGridMov3(1, 0, -1, 0)) {
+		t.Errorf("Going on with the last step should not touch the trajectory")
+	}
+}
@@ -8,6 +8,10 @@ type GridMov2 struct {
 	dCol    int
 }
 
+func NewGridMov2(pieceId int, dRow int, dCol int) *GridMov2 {
+	return &GridMov2{pieceId, dRow, dCol}
+}
+
 func (m *GridMov2) PieceId() int {
 	return m.pieceId
 }
@@ -0,0 +1,83 @@
+package grids
+
+import "fmt"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Translation of a polycube on a Matrix3d
+type GridMov3 struct {
+	pieceId int
+	dLayer  int
+	dRow    int
+	dCol    int
+}
+
+// The six steps of a polycube: down, up, right, left, and to the next or previous layer
+var s_voxelSteps_ = []Coords3{{0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}, {1, 0, 0}, {-1, 0, 0}}
+
+func NewGridMov3(pieceId int, dLayer int, dRow int, dCol int) *GridMov3 {
+	return &GridMov3{pieceId, dLayer, dRow, dCol}
+}
+
+func (m *GridMov3) PieceId() int {
+	return m.pieceId
+}
+
+func (m *GridMov3) Translation() (dLayer int, dRow int, dCol int) {
+	return m.dLayer, m.dRow, m.dCol
+}
+
+func (m *GridMov3) Inverted() interface{} {
+	return &GridMov3{m.pieceId, -m.dLayer, -m.dRow, -m.dCol}
+}
+
+func (m *GridMov3) IsInverse(x interface{}) bool {
+	y, ok := x.(*GridMov3)
+	if !ok {
+		panic("[GridMov3::IsInverse] param is not a GridMov3 instance!")
+	}
+	return m.pieceId == y.pieceId && m.dLayer+y.dLayer == 0 && m.dRow+y.dRow == 0 && m.dCol+y.dCol == 0
+}
+
+func (m *GridMov3) Equals(x interface{}) bool {
+	y, ok := x.(*GridMov3)
+	return ok && *m == *y
+}
+
+// JSON format
+func (m *GridMov3) Print() {
+	fmt.Printf("[%d, %d, %d, %d]", m.pieceId, m.dLayer, m.dRow, m.dCol)
+}
+
+// Same as TrajectoryTouchesWithMov, for polycubes
+func TrajectoryTouchesWithMov3(invertedPath []defs.Command, mov defs.Command) bool {
+
+	// As on squares, the shortest loop is 4 steps
+	if len(invertedPath) < 2 || invertedPath[0].PieceId() != mov.PieceId() {
+		return false
+	}
+
+	gMov, ok := mov.(*GridMov3)
+	if !ok {
+		panic("[grids::TrajectoryTouchesWithMov3] mov is not a GridMov3!")
+	}
+
+	// Origin at the position after mov: walk back the path, looking for a position adjacent to the origin
+	position := Coords3{-gMov.dLayer, -gMov.dRow, -gMov.dCol}
+	for _, m := range invertedPath {
+		mInv := m.Inverted().(*GridMov3)
+		position[0] += mInv.dLayer
+		position[1] += mInv.dRow
+		position[2] += mInv.dCol
+
+		if position == (Coords3{-gMov.dLayer, -gMov.dRow, -gMov.dCol}) {
+			panic("[grids::TrajectoryTouchesWithMov3] algorithm detecting loop inside path!")
+		}
+		for _, s := range s_voxelSteps_ {
+			if position == s {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -47,9 +47,18 @@ func PathFromSlice(path []defs.Command) *GridPath2 {
  * where 'y' is the new position casued by mov: it touches the trajectory on its top face!
  */
 func TrajectoryTouchesWithMov(invertedPath []defs.Command, mov defs.Command) bool {
+	return LatticeTrajectoryTouchesWithMov(SQUARE_LATTICE, invertedPath, mov)
+}
+
+// Same as TrajectoryTouchesWithMov, with the steps of the lattice
+func LatticeTrajectoryTouchesWithMov(l Lattice, invertedPath []defs.Command, mov defs.Command) bool {
 
-	// Short paths cannot have loops, the shortest one is 3-length
-	if len(invertedPath) < 2 {
+	// Short paths cannot have loops, the shortest one is 3-length (2-length with triangles of steps)
+	shortest := 2
+	if l != SQUARE_LATTICE {
+		shortest = 1
+	}
+	if len(invertedPath) < shortest {
 		return false
 	}
 
@@ -93,8 +102,8 @@ func TrajectoryTouchesWithMov(invertedPath []defs.Command, mov defs.Command) boo
 			panic("[grids::TrajectoryTouchesWithMov] algorithm detecting loop inside path!")
 		}
 
-		// Adjacent positions are (-1, 0), (1, 0), (0, 1) and (0, -1), the only ones with module 1:
-		if position[0]*position[0]+position[1]*position[1] == 1 {
+		// Adjacent positions are one step away
+		if l.Adjacent(position[0], position[1]) {
 
 			// fmt.Println("**** DETECTED LOOP!: [grids::TrajectoryTouchesWithMov] algorithm detecting loop inside path!")
 			// fmt.Println("\nMOV:", mov)
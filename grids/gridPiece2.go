@@ -43,6 +43,11 @@ func (p *GridPiece2) IsAt(row int, col int) bool {
 	return p.position_[0] == row && p.position_[1] == col
 }
 
+// Position of the piece boundary box
+func (p *GridPiece2) Position() Coords2 {
+	return p.position_
+}
+
 func (p *GridPiece2) Len() int {
 	return len(p.cells_)
 }
@@ -139,17 +144,25 @@ func (p *GridPiece2) SetPositionTL(row int, col int) {
 }
 
 func (p *GridPiece2) Move(m interface{}) {
-	mov, ok := m.(*GridMov2)
-	if ok {
+	switch mov := m.(type) {
+	case *GridMov2:
+		p.position_[0] += mov.dRow
+		p.position_[1] += mov.dCol
+	case *CompoundMov2:
 		p.position_[0] += mov.dRow
 		p.position_[1] += mov.dCol
-	} else {
+	default:
 		panic("[GridPiece2::Move] Unknown type of move!")
 	}
 }
 
 // Sets the same value for all alike pieces (those with exactly the same shape)
 func DetectAlikePieces(pieces []*GridPiece2, minValue int) {
+	DetectLatticeAlikePieces(pieces, minValue, SQUARE_LATTICE)
+}
+
+// Same as DetectAlikePieces, with pieces of the same shape pointing the same way on the lattice
+func DetectLatticeAlikePieces(pieces []*GridPiece2, minValue int, l Lattice) {
 
 	// Save a representant for each piece
 	var shapeClasses []*GridPiece2
@@ -161,7 +174,7 @@ func DetectAlikePieces(pieces []*GridPiece2, minValue int) {
 		found := false
 		foundIdx := 0
 		for i, q := range shapeClasses {
-			if p.Equivalent(q) {
+			if p.Equivalent(q) && l.Orientation(p) == l.Orientation(q) {
 				//fmt.Printf("\n		- equivalent pieces: %d - %d", p.Id(), q.Id())
 				found = true
 				foundIdx = i
@@ -0,0 +1,141 @@
+package grids
+
+type Coords3 [3]int
+
+// Definition of a static polycube: does not contain status, like position. The 3D analogue of GridPiece2,
+// with cells as (layer, row, col).
+type GridPiece3 struct {
+	pieceId_  int
+	value_    int
+	cells_    []Coords3
+	position_ Coords3
+
+	// First cell we would see reading the grid layer by layer, each one top to bottom, left to right
+	tl_ Coords3
+}
+
+func NewGridPiece3(id int, val int) *GridPiece3 {
+	value := val
+	if val == 0 {
+		value = id
+	}
+	huge := Coords3{PIECE_HUGE_SIZE, PIECE_HUGE_SIZE, PIECE_HUGE_SIZE}
+	return &GridPiece3{id, value, nil, huge, huge}
+}
+
+func (p *GridPiece3) Id() int {
+	return p.pieceId_
+}
+
+func (p *GridPiece3) Value() int {
+	return p.value_
+}
+
+func (p *GridPiece3) IsAt(layer int, row int, col int) bool {
+	return p.position_ == Coords3{layer, row, col}
+}
+
+// Position of the piece boundary box
+func (p *GridPiece3) Position() Coords3 {
+	return p.position_
+}
+
+func (p *GridPiece3) Len() int {
+	return len(p.cells_)
+}
+
+func (p *GridPiece3) Cell(i int) *Coords3 {
+	return &p.cells_[i]
+}
+
+func (p *GridPiece3) SetValue(v int) {
+	p.value_ = v
+}
+
+// True if the pieces have the same shape and orientation
+func (p *GridPiece3) Equivalent(q *GridPiece3) bool {
+	if len(p.cells_) != len(q.cells_) {
+		return false
+	}
+
+	// Cells are added in reading order, so same shapes have the same cells in the same order
+	for i, c := range p.cells_ {
+		if c != q.cells_[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Cells must be added in reading order (see Matrix3d.GeneratePieces)
+func (p *GridPiece3) AddCell(c Coords3) *GridPiece3 {
+	p.cells_ = append(p.cells_, c)
+
+	for i := 0; i < 3; i++ {
+		if c[i] < p.position_[i] {
+			p.position_[i] = c[i]
+		}
+	}
+	if len(p.cells_) == 1 {
+		p.tl_ = c
+	}
+	return p
+}
+
+// Makes the boundary box origin the origin of the cells
+func (p *GridPiece3) FixPosition() {
+	for i := range p.cells_ {
+		for j := 0; j < 3; j++ {
+			p.cells_[i][j] -= p.position_[j]
+		}
+	}
+	for j := 0; j < 3; j++ {
+		p.tl_[j] -= p.position_[j]
+	}
+}
+
+func (p *GridPiece3) SetPosition(layer int, row int, col int) {
+	p.position_ = Coords3{layer, row, col}
+}
+
+// Positions the piece from the cell where a reading of the grid finds it first (see
+// GridPiece2.SetPositionTL)
+func (p *GridPiece3) SetPositionTL(layer int, row int, col int) {
+	p.position_ = Coords3{layer - p.tl_[0], row - p.tl_[1], col - p.tl_[2]}
+}
+
+func (p *GridPiece3) Move(m interface{}) {
+	mov, ok := m.(*GridMov3)
+	if !ok {
+		panic("[GridPiece3::Move] Unknown type of move!")
+	}
+	p.position_[0] += mov.dLayer
+	p.position_[1] += mov.dRow
+	p.position_[2] += mov.dCol
+}
+
+// Sets the same value for all alike polycubes (those with exactly the same shape and orientation)
+func DetectAlikePieces3(pieces []*GridPiece3, minValue int) {
+
+	// Save a representant for each shape
+	var shapeClasses []*GridPiece3
+	var values []int
+
+	value := minValue
+	for _, p := range pieces {
+		found := false
+		for i, q := range shapeClasses {
+			if p.Equivalent(q) {
+				p.SetValue(values[i])
+				found = true
+				break
+			}
+		}
+		if !found {
+			shapeClasses = append(shapeClasses, p)
+			values = append(values, value)
+			p.SetValue(value)
+			value++
+		}
+	}
+}
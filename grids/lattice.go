@@ -0,0 +1,85 @@
+package grids
+
+// Shape of the cells of a grid. Cells are stored in a Matrix2d whatever their shape, and pieces move by
+// translations (GridMov2) taking the grid to itself, so boards and moves work on any lattice.
+type Lattice int
+
+const (
+	// Square cells. 4 steps: down, up, right and left.
+	SQUARE_LATTICE Lattice = iota
+
+	// Hexagonal cells in axial coordinates: each row is drawn half a cell to the right of the previous
+	// one. 6 steps: the square ones, and the up-right (-1, 1) and down-left (1, -1) diagonals.
+	HEX_LATTICE
+
+	// Triangles, pointing up when row+col is even and down otherwise. 6 steps, along their sides: one
+	// row and col down or up (±1, ±1), or two cols right or left (0, ±2), keeping the orientation.
+	TRIANGLE_LATTICE
+)
+
+var s_latticeSteps_ = [][]Coords2{
+	SQUARE_LATTICE:   {{1, 0}, {-1, 0}, {0, 1}, {0, -1}},
+	HEX_LATTICE:      {{1, 0}, {-1, 0}, {0, 1}, {0, -1}, {-1, 1}, {1, -1}},
+	TRIANGLE_LATTICE: {{1, 1}, {1, -1}, {-1, 1}, {-1, -1}, {0, 2}, {0, -2}},
+}
+
+var s_latticeNames_ = []string{"square", "hex", "triangle"}
+
+// Translations moving a piece to an adjacent position
+func (l Lattice) Steps() []Coords2 {
+	return s_latticeSteps_[l]
+}
+
+// True if the translation is a step
+func (l Lattice) Adjacent(dRow int, dCol int) bool {
+	for _, s := range s_latticeSteps_[l] {
+		if s[0] == dRow && s[1] == dCol {
+			return true
+		}
+	}
+	return false
+}
+
+func (l Lattice) String() string {
+	return s_latticeNames_[l]
+}
+
+// Way the piece points, which steps keep: on triangle lattices, whether its origin cell points up (0)
+// or down (1). Pieces of the same shape are alike only if they point the same way.
+func (l Lattice) Orientation(p *GridPiece2) int {
+	if l != TRIANGLE_LATTICE {
+		return 0
+	}
+	return (p.position_[0] + p.position_[1]) % 2
+}
+
+// Lattice by its name (square, hex or triangle)
+func LatticeByName(name string) (Lattice, bool) {
+	for l, n := range s_latticeNames_ {
+		if n == name {
+			return Lattice(l), true
+		}
+	}
+	return SQUARE_LATTICE, false
+}
+
+// Steps the piece can do on the board, in the order of the lattice steps. On the square lattice, the
+// same as Board.PieceMovements.
+func LatticeMovements(b Board, p *GridPiece2, l Lattice) []*GridMov2 {
+	if l == SQUARE_LATTICE {
+		return b.PieceMovements(p)
+	}
+
+	var movs []*GridMov2
+	for _, s := range s_latticeSteps_[l] {
+		if b.CanPieceMove(p, s[0], s[1]) {
+			movs = append(movs, &GridMov2{p.Id(), s[0], s[1]})
+		}
+	}
+	return movs
+}
+
+// Whole piece moves on the board, through chains of lattice steps (see Matrix2d.PieceReachableMovs)
+func LatticeReachableMovs(b Board, p *GridPiece2, l Lattice) []*CompoundMov2 {
+	return reachableMovs(b, p, l)
+}
@@ -0,0 +1,213 @@
+package grids
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Board storing only the position of each piece, and a bitboard of the occupied cells. Moves and
+// hashes take time proportional to the number of pieces, not to the board area: for large boards with
+// lots of free space.
+type SparseBoard struct {
+	layout_ *pieceLayout
+
+	// Position (boundary box) of each piece, by slot
+	pos_      []Coords2
+	occupied_ []uint64
+}
+
+// Sparse board with the cells of m, holding the pieces (built from m, see SBGame.Build)
+func NewSparseBoard(m *Matrix2d, pieces []*GridPiece2) *SparseBoard {
+	return newPieceLayout(m, pieces).sparseBoard(m)
+}
+
+func (l *pieceLayout) sparseBoard(m *Matrix2d) *SparseBoard {
+	b := &SparseBoard{
+		layout_:   l,
+		occupied_: make([]uint64, (l.rows_*l.cols_+63)/64),
+	}
+	b.pos_ = l.positions(m)
+	for s, pos := range b.pos_ {
+		for _, cell := range l.cells_[s] {
+			b.set(pos[0]+cell[0], pos[1]+cell[1])
+		}
+	}
+	return b
+}
+
+func (b *SparseBoard) Rows() int {
+	return b.layout_.rows_
+}
+
+func (b *SparseBoard) Cols() int {
+	return b.layout_.cols_
+}
+
+func (b *SparseBoard) At(row int, col int) int {
+	if !b.isSet(row, col) {
+		return 0
+	}
+	for s := range b.pos_ {
+		if b.covers(s, row, col) {
+			return b.layout_.ids_[s]
+		}
+	}
+	return 0
+}
+
+// Builds a new matrix on each call
+func (b *SparseBoard) Matrix() *Matrix2d {
+	return b.layout_.matrix(b.pos_)
+}
+
+func (b *SparseBoard) CloneBoard() Board {
+	c := &SparseBoard{
+		layout_:   b.layout_,
+		pos_:      make([]Coords2, len(b.pos_)),
+		occupied_: make([]uint64, len(b.occupied_)),
+	}
+	copy(c.pos_, b.pos_)
+	copy(c.occupied_, b.occupied_)
+	return c
+}
+
+func (b *SparseBoard) BoardFrom(m *Matrix2d) Board {
+	return b.layout_.sparseBoard(m)
+}
+
+// Boards are equal if each piece value is at the same positions
+func (b *SparseBoard) SameValues(o Board, ptv *defs.PieceToValue) bool {
+	ob, ok := o.(*SparseBoard)
+	if !ok {
+		return b.Matrix().SameValues(o, ptv)
+	}
+	return b.layout_.sameKeys(b.pos_, ob.pos_, ptv)
+}
+
+// False if t has no piece
+func (b *SparseBoard) SubValues(t Board, ptv *defs.PieceToValue) bool {
+	return subValues(b, t, ptv)
+}
+
+func (b *SparseBoard) HashValues(ptv *defs.PieceToValue) int {
+	return b.layout_.hash(b.pos_, ptv)
+}
+
+// Returns array of valid movements for this piece
+func (b *SparseBoard) PieceMovements(p *GridPiece2) (movs []*GridMov2) {
+	s := b.layout_.slot(p.Id())
+	if b.canMove(s, 1, 0) {
+		movs = append(movs, &GridMov2{p.Id(), 1, 0})
+	}
+	if b.canMove(s, -1, 0) {
+		movs = append(movs, &GridMov2{p.Id(), -1, 0})
+	}
+	if b.canMove(s, 0, 1) {
+		movs = append(movs, &GridMov2{p.Id(), 0, 1})
+	}
+	if b.canMove(s, 0, -1) {
+		movs = append(movs, &GridMov2{p.Id(), 0, -1})
+	}
+	return movs
+}
+
+func (b *SparseBoard) CanPieceMove(p *GridPiece2, dRow int, dCol int) bool {
+	return b.canMove(b.layout_.slot(p.Id()), dRow, dCol)
+}
+
+func (b *SparseBoard) ValidMove(mov GridMov2) bool {
+	return b.canMove(b.layout_.slot(mov.PieceId()), mov.dRow, mov.dCol)
+}
+
+func (b *SparseBoard) PieceReachableMovs(p *GridPiece2) []*CompoundMov2 {
+	return reachableMovs(b, p, SQUARE_LATTICE)
+}
+
+// True if the piece of the slot can be translated: its cells stay on the board, on free cells or on
+// its own cells
+func (b *SparseBoard) canMove(s int, dRow int, dCol int) bool {
+	l := b.layout_
+	pos := b.pos_[s]
+	for _, cell := range l.cells_[s] {
+		row := pos[0] + cell[0] + dRow
+		col := pos[1] + cell[1] + dCol
+
+		if row < 0 || row >= l.rows_ || col < 0 || col >= l.cols_ {
+			return false
+		}
+		if b.isSet(row, col) && !b.covers(s, row, col) {
+			return false
+		}
+	}
+	return true
+}
+
+// Removes the piece, which must be at its position
+func (b *SparseBoard) ClearPiece(p *GridPiece2) {
+	s := b.layout_.slot(p.Id())
+	if b.pos_[s] != p.position_ {
+		panic("[SparseBoard::ClearPiece] piece not at its position!")
+	}
+	b.clearSlot(s)
+}
+
+// Puts the piece at its position, on free cells
+func (b *SparseBoard) PlacePiece(p *GridPiece2) {
+	b.placeSlot(b.layout_.slot(p.Id()), p.position_)
+}
+
+func (b *SparseBoard) ApplyRawTranslation(pieceId int, mov GridMov2) {
+	s := b.layout_.slot(pieceId)
+	b.clearSlot(s)
+	b.placeSlot(s, Coords2{b.pos_[s][0] + mov.dRow, b.pos_[s][1] + mov.dCol})
+}
+
+func (b *SparseBoard) UpdatePiecePositions(piecesById map[int]*GridPiece2) {
+	for s, id := range b.layout_.ids_ {
+		if p, ok := piecesById[id]; ok {
+			p.SetPosition(b.pos_[s][0], b.pos_[s][1])
+		}
+	}
+}
+
+func (b *SparseBoard) PieceCells(pieceId int) []Coords2 {
+	s := b.layout_.slot(pieceId)
+	return b.layout_.pieceCells(s, b.pos_[s])
+}
+
+func (b *SparseBoard) clearSlot(s int) {
+	pos := b.pos_[s]
+	for _, cell := range b.layout_.cells_[s] {
+		b.clear(pos[0]+cell[0], pos[1]+cell[1])
+	}
+}
+
+func (b *SparseBoard) placeSlot(s int, pos Coords2) {
+	l := b.layout_
+	for _, cell := range l.cells_[s] {
+		row := pos[0] + cell[0]
+		col := pos[1] + cell[1]
+		if row < 0 || row >= l.rows_ || col < 0 || col >= l.cols_ || b.isSet(row, col) {
+			panic("[SparseBoard::placeSlot] invalid movement!")
+		}
+		b.set(row, col)
+	}
+	b.pos_[s] = pos
+}
+
+// True if the piece of the slot covers the cell
+func (b *SparseBoard) covers(s int, row int, col int) bool {
+	return b.layout_.covers(s, b.pos_[s], row, col)
+}
+
+func (b *SparseBoard) isSet(row int, col int) bool {
+	i := row*b.layout_.cols_ + col
+	return b.occupied_[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (b *SparseBoard) set(row int, col int) {
+	i := row*b.layout_.cols_ + col
+	b.occupied_[i/64] |= 1 << uint(i%64)
+}
+
+func (b *SparseBoard) clear(row int, col int) {
+	i := row*b.layout_.cols_ + col
+	b.occupied_[i/64] &^= 1 << uint(i%64)
+}
@@ -0,0 +1,101 @@
+package grids
+
+// Symmetries of a rectangular board. Each one is its own inverse, and composing two of them gives
+// the xor of their values.
+type Symmetry int
+
+const (
+	IDENTITY   Symmetry = 0
+	MIRROR_LR  Symmetry = 1 // Left-right reflection
+	MIRROR_UD  Symmetry = 2 // Up-down reflection
+	ROTATE_180 Symmetry = 3
+)
+
+// Symmetries other than the identity
+var SYMMETRIES = []Symmetry{MIRROR_LR, MIRROR_UD, ROTATE_180}
+
+// Symmetry doing s, then t
+func (s Symmetry) Compose(t Symmetry) Symmetry {
+	return s ^ t
+}
+
+// Cell where the symmetry takes (row, col), on a board of that size
+func (s Symmetry) Cell(rows int, cols int, row int, col int) (int, int) {
+	if s&MIRROR_LR != 0 {
+		col = cols - 1 - col
+	}
+	if s&MIRROR_UD != 0 {
+		row = rows - 1 - row
+	}
+	return row, col
+}
+
+// Translation the symmetry takes (dRow, dCol) to
+func (s Symmetry) Translation(dRow int, dCol int) (int, int) {
+	if s&MIRROR_LR != 0 {
+		dCol = -dCol
+	}
+	if s&MIRROR_UD != 0 {
+		dRow = -dRow
+	}
+	return dRow, dCol
+}
+
+func (s Symmetry) String() string {
+	switch s {
+	case IDENTITY:
+		return "identity"
+	case MIRROR_LR:
+		return "left-right mirror"
+	case MIRROR_UD:
+		return "up-down mirror"
+	case ROTATE_180:
+		return "180 degrees rotation"
+	}
+	return "unknown symmetry"
+}
+
+// Returns the transformed matrix: the cell (r, c) goes to s.Cell(rows, cols, r, c)
+func (g *Matrix2d) Transformed(s Symmetry) Matrix2d {
+	rows := g.Rows()
+	cols := g.Cols()
+
+	m := make(Matrix2d, rows)
+	for r := 0; r < rows; r++ {
+		m[r] = make([]int, cols)
+	}
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			r2, c2 := s.Cell(rows, cols, r, c)
+			m[r2][c2] = (*g)[r][c]
+		}
+	}
+	return m
+}
+
+// True if the symmetry keeps the piece shape, up to a translation
+func (p *GridPiece2) SymmetricUnder(s Symmetry) bool {
+
+	// Size of the boundary box
+	rows, cols := 0, 0
+	for _, c := range p.cells_ {
+		if c[0]+1 > rows {
+			rows = c[0] + 1
+		}
+		if c[1]+1 > cols {
+			cols = c[1] + 1
+		}
+	}
+
+	cells := make(map[Coords2]bool)
+	for _, c := range p.cells_ {
+		cells[c] = true
+	}
+	for _, c := range p.cells_ {
+		r, col := s.Cell(rows, cols, c[0], c[1])
+		if !cells[Coords2{r, col}] {
+			return false
+		}
+	}
+	return true
+}
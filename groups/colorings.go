@@ -0,0 +1,264 @@
+package groups
+
+import "errors"
+import "fmt"
+import "math/big"
+
+const (
+	// Limits for the exhaustive fallbacks of Solvable
+	MAX_COSETS       = 1 << 12
+	MAX_ORBIT_STATES = 1 << 22
+)
+
+// Subgroup of the elements that keep a coloring of the points: coloring[p[i]] == coloring[i] for every i.
+// Puzzles with alike pieces color each slot with its piece value; the subgroup holds the permutations
+// that only swap alike pieces.
+func (g *PermGroup) ColoringStabilizer(coloring []int) *PermGroup {
+	if len(coloring) != g.n_ {
+		panic("[PermGroup::ColoringStabilizer] coloring size does not match group degree!")
+	}
+
+	// Subgroup search, from the deepest level up. At level l the subgroup found so far fixes b_0..b_l; an
+	// element is searched for each point in the basic orbit not yet reached by it.
+	k := len(g.base_)
+	levelGens := make([][]Perm, k)
+	var cur []Perm
+
+	for l := k - 1; l >= 0; l-- {
+		b := g.base_[l]
+		reached := pointSet(orbitOf(b, cur))
+
+		for _, x := range g.orbits_[l] {
+			if reached[x] || coloring[x] != coloring[b] {
+				continue
+			}
+			if s := g.searchElement(l+1, g.trans_[l][x], coloring, coloring); s != nil {
+				levelGens[l] = append(levelGens[l], s)
+				cur = append(cur, s)
+				reached = pointSet(orbitOf(b, cur))
+			}
+		}
+	}
+	return newPermGroupWithBase(g.n_, g.base_, levelGens)
+}
+
+// Number of different colorings reached from 'coloring': the group order divided by the order of its
+// stabilizer. For a puzzle, the number of distinct states once alike pieces are taken into account.
+func (g *PermGroup) OrbitSize(coloring []int) *big.Int {
+	size := g.Order()
+	return size.Div(size, g.ColoringStabilizer(coloring).Order())
+}
+
+// Returns true if some element of the group moves the coloring 'from' into the coloring 'to', i.e. the
+// target state can be reached from the start state.
+func (g *PermGroup) Solvable(from []int, to []int) (bool, error) {
+	if len(from) != g.n_ || len(to) != g.n_ {
+		return false, errors.New("colorings size does not match group degree")
+	}
+
+	// Group elements never leave the orbits of the points: colors are refined by orbit, so that the search
+	// only considers permutations inside them.
+	from, to = g.refineByOrbits(from, to)
+
+	// Any permutation taking 'from' to 'to'; none if colors differ.
+	sigma := matchColorings(from, to)
+	if sigma == nil {
+		return false, nil
+	}
+
+	// Solutions are sigma followed by an element of Y, the permutations that keep 'to'. Each coset of
+	// K = G n Y in Y contributes entirely or not at all, so one representative per coset is enough.
+	k := g.ColoringStabilizer(to)
+	index := coloringSymmetryOrder(to)
+	index.Div(index, k.Order())
+
+	if index.Cmp(big.NewInt(MAX_COSETS)) <= 0 {
+		for _, y := range cosetRepresentatives(k, coloringSymmetryGenerators(to), int(index.Int64())) {
+			if g.Contains(sigma.Mul(y)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	// Too many cosets: walk the orbit of 'from' if it is small enough.
+	if g.OrbitSize(from).Cmp(big.NewInt(MAX_ORBIT_STATES)) <= 0 {
+		return g.orbitContains(from, to), nil
+	}
+	return false, errors.New("coloring orbit too large to decide membership")
+}
+
+// Recolors both colorings with a new color for each pair (point orbit, color).
+func (g *PermGroup) refineByOrbits(from []int, to []int) ([]int, []int) {
+	orbitId := make([]int, g.n_)
+	for i := range orbitId {
+		orbitId[i] = -1
+	}
+	numOrbits := 0
+	for i := range orbitId {
+		if orbitId[i] < 0 {
+			for _, x := range g.Orbit(i) {
+				orbitId[x] = numOrbits
+			}
+			numOrbits++
+		}
+	}
+
+	colors := make(map[[2]int]int)
+	refine := func(coloring []int) []int {
+		r := make([]int, len(coloring))
+		for i, c := range coloring {
+			key := [2]int{orbitId[i], c}
+			if _, ok := colors[key]; !ok {
+				colors[key] = len(colors)
+			}
+			r[i] = colors[key]
+		}
+		return r
+	}
+	return refine(from), refine(to)
+}
+
+// Depth first search of an element of G^(l)*tail moving 'from' into 'to'. Elements are enumerated as
+// products of transversal elements; each level fixes the image of one base point, which must keep its color.
+func (g *PermGroup) searchElement(l int, tail Perm, from []int, to []int) Perm {
+	if l == len(g.base_) {
+		for i, x := range tail {
+			if to[x] != from[i] {
+				return nil
+			}
+		}
+		return tail
+	}
+
+	b := g.base_[l]
+	for _, x := range g.orbits_[l] {
+		// Deeper levels fix b_l, so its final image is tail[x]
+		if to[tail[x]] != from[b] {
+			continue
+		}
+		if s := g.searchElement(l+1, g.trans_[l][x].Mul(tail), from, to); s != nil {
+			return s
+		}
+	}
+	return nil
+}
+
+// Breadth first walk over the colorings reached from 'from'.
+func (g *PermGroup) orbitContains(from []int, to []int) bool {
+	key := func(c []int) string {
+		return fmt.Sprint(c)
+	}
+
+	target := key(to)
+	visited := map[string]bool{key(from): true}
+	queue := [][]int{from}
+
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if key(c) == target {
+			return true
+		}
+		for _, s := range g.Generators() {
+			next := s.Apply(c)
+			if k := key(next); !visited[k] {
+				visited[k] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return false
+}
+
+// One representative y of each coset yK of k inside the group generated by 'gens'. Cosets are reached by
+// multiplying representatives by the generators.
+func cosetRepresentatives(k *PermGroup, gens []Perm, index int) []Perm {
+	reps := []Perm{Identity(k.n_)}
+
+	for i := 0; i < len(reps) && len(reps) < index; i++ {
+		for _, s := range gens {
+			candidate := s.Mul(reps[i])
+
+			known := false
+			for _, r := range reps {
+				if k.Contains(r.Inverse().Mul(candidate)) {
+					known = true
+					break
+				}
+			}
+			if !known {
+				reps = append(reps, candidate)
+			}
+		}
+	}
+	return reps
+}
+
+// Returns a permutation sigma with to[sigma[i]] == from[i], or nil if the colorings have different colors.
+func matchColorings(from []int, to []int) Perm {
+	free := make(map[int][]int)
+	for i, c := range to {
+		free[c] = append(free[c], i)
+	}
+
+	sigma := make(Perm, len(from))
+	for i, c := range from {
+		if len(free[c]) == 0 {
+			return nil
+		}
+		sigma[i] = free[c][0]
+		free[c] = free[c][1:]
+	}
+	return sigma
+}
+
+// Order of the group of all permutations keeping the coloring: product of the factorials of the color
+// class sizes.
+func coloringSymmetryOrder(coloring []int) *big.Int {
+	order := big.NewInt(1)
+	for _, class := range colorClasses(coloring) {
+		f := new(big.Int).MulRange(1, int64(len(class)))
+		order.Mul(order, f)
+	}
+	return order
+}
+
+// A transposition and a full cycle for each color class generate all the permutations keeping the coloring.
+func coloringSymmetryGenerators(coloring []int) []Perm {
+	var gens []Perm
+	n := len(coloring)
+	for _, class := range colorClasses(coloring) {
+		if len(class) < 2 {
+			continue
+		}
+		gens = append(gens, NewPermFromCycles(n, [][]int{class[:2]}))
+		if len(class) > 2 {
+			gens = append(gens, NewPermFromCycles(n, [][]int{class}))
+		}
+	}
+	return gens
+}
+
+func colorClasses(coloring []int) [][]int {
+	var classes [][]int
+	classOf := make(map[int]int)
+	for i, c := range coloring {
+		idx, ok := classOf[c]
+		if !ok {
+			idx = len(classes)
+			classOf[c] = idx
+			classes = append(classes, nil)
+		}
+		classes[idx] = append(classes[idx], i)
+	}
+	return classes
+}
+
+func pointSet(points []int) map[int]bool {
+	set := make(map[int]bool, len(points))
+	for _, x := range points {
+		set[x] = true
+	}
+	return set
+}
@@ -0,0 +1,102 @@
+package groups
+
+import "testing"
+
+func symmetricGroup(n int) *PermGroup {
+	cycle := make([]int, n)
+	for i := range cycle {
+		cycle[i] = i
+	}
+	return NewPermGroup(n, []Perm{
+		NewPermFromCycles(n, [][]int{{0, 1}}),
+		NewPermFromCycles(n, [][]int{cycle}),
+	})
+}
+
+func TestGroupOrder(t *testing.T) {
+	if order := symmetricGroup(6).Order().Int64(); order != 720 {
+		t.Errorf("Symmetric group S6 order: %d, should be 720", order)
+	}
+
+	// 3-cycles generate the alternating group
+	alt := NewPermGroup(5, []Perm{
+		NewPermFromCycles(5, [][]int{{0, 1, 2}}),
+		NewPermFromCycles(5, [][]int{{1, 2, 3}}),
+		NewPermFromCycles(5, [][]int{{2, 3, 4}}),
+	})
+	if order := alt.Order().Int64(); order != 60 {
+		t.Errorf("Alternating group A5 order: %d, should be 60", order)
+	}
+	if alt.Contains(NewPermFromCycles(5, [][]int{{0, 1}})) {
+		t.Errorf("A5 should not contain a transposition")
+	}
+	if !alt.Contains(NewPermFromCycles(5, [][]int{{0, 1}, {2, 3}})) {
+		t.Errorf("A5 should contain a double transposition")
+	}
+
+	// Two 12-slot wheels sharing three slots, as Engel puzzles. Turns keep the 11 triangle slots and the 10
+	// rectangle slots apart, and are even: the group is the even half of S11 x S10.
+	wheel := func(slots []int) Perm {
+		var a, b []int
+		for i, s := range slots {
+			if i%2 == 0 {
+				a = append(a, s)
+			} else {
+				b = append(b, s)
+			}
+		}
+		return NewPermFromCycles(21, [][]int{a, b})
+	}
+	left := wheel([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+	right := wheel([]int{12, 13, 14, 15, 16, 5, 6, 7, 17, 18, 19, 20})
+	g1 := NewPermGroup(21, []Perm{left, right})
+	g2 := NewPermGroup(21, []Perm{right, left})
+	if order := g1.Order().Int64(); order != 39916800*3628800/2 {
+		t.Errorf("Engel wheels group order: %d, should be 11! * 10! / 2", order)
+	}
+	if g1.Order().Cmp(g2.Order()) != 0 {
+		t.Errorf("Group order depends on generators order: %v != %v", g1.Order(), g2.Order())
+	}
+}
+
+func TestColoringOrbits(t *testing.T) {
+	coloring := []int{1, 1, 2, 2}
+
+	if size := symmetricGroup(4).OrbitSize(coloring).Int64(); size != 6 {
+		t.Errorf("S4 orbit of 1122: %d, should be 6", size)
+	}
+
+	rotations := NewPermGroup(4, []Perm{NewPermFromCycles(4, [][]int{{0, 1, 2, 3}})})
+	if size := rotations.OrbitSize(coloring).Int64(); size != 4 {
+		t.Errorf("C4 orbit of 1122: %d, should be 4", size)
+	}
+	if size := rotations.OrbitSize([]int{1, 2, 1, 2}).Int64(); size != 2 {
+		t.Errorf("C4 orbit of 1212: %d, should be 2", size)
+	}
+}
+
+func TestSolvable(t *testing.T) {
+	alt := NewPermGroup(4, []Perm{
+		NewPermFromCycles(4, [][]int{{0, 1, 2}}),
+		NewPermFromCycles(4, [][]int{{1, 2, 3}}),
+	})
+
+	cases := []struct {
+		from, to []int
+		result   bool
+	}{
+		{[]int{1, 2, 3, 4}, []int{2, 3, 1, 4}, true},
+		{[]int{1, 2, 3, 4}, []int{2, 1, 3, 4}, false},
+		{[]int{1, 1, 3, 4}, []int{1, 1, 4, 3}, true},
+		{[]int{1, 2, 3, 4}, []int{1, 2, 3, 5}, false},
+	}
+
+	for _, c := range cases {
+		result, err := alt.Solvable(c.from, c.to)
+		if err != nil {
+			t.Errorf("Solvable %v -> %v failed: %v", c.from, c.to, err)
+		} else if result != c.result {
+			t.Errorf("Solvable %v -> %v: %v, should be %v", c.from, c.to, result, c.result)
+		}
+	}
+}
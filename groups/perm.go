@@ -0,0 +1,73 @@
+package groups
+
+// A permutation of the points 0..n-1: p[i] is the image of i.
+// When it permutes puzzle slots, the piece at slot i moves to slot p[i].
+type Perm []int
+
+// Identity permutation of n points
+func Identity(n int) Perm {
+	p := make(Perm, n)
+	for i := range p {
+		p[i] = i
+	}
+	return p
+}
+
+// Builds a permutation of n points from disjoint cycles: cycle[i] goes to cycle[i+1].
+func NewPermFromCycles(n int, cycles [][]int) Perm {
+	p := Identity(n)
+	for _, cycle := range cycles {
+		l := len(cycle)
+		for j, point := range cycle {
+			p[point] = cycle[(j+1)%l]
+		}
+	}
+	return p
+}
+
+func (p Perm) IsIdentity() bool {
+	for i, x := range p {
+		if i != x {
+			return false
+		}
+	}
+	return true
+}
+
+func (p Perm) Equal(q Perm) bool {
+	if len(p) != len(q) {
+		return false
+	}
+	for i, x := range p {
+		if q[i] != x {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the permutation 'p followed by q'
+func (p Perm) Mul(q Perm) Perm {
+	r := make(Perm, len(p))
+	for i, x := range p {
+		r[i] = q[x]
+	}
+	return r
+}
+
+func (p Perm) Inverse() Perm {
+	r := make(Perm, len(p))
+	for i, x := range p {
+		r[x] = i
+	}
+	return r
+}
+
+// Moves slot contents: the content at slot i goes to slot p[i]. Returns a new slice.
+func (p Perm) Apply(slots []int) []int {
+	r := make([]int, len(slots))
+	for i, x := range slots {
+		r[p[i]] = x
+	}
+	return r
+}
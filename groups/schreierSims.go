@@ -0,0 +1,204 @@
+package groups
+
+import "math/big"
+
+// Permutation group given by a base and strong generating set (BSGS), built with the Schreier-Sims
+// algorithm. Level l holds the generators of the stabilizer of base points b_0..b_{l-1}, the orbit of b_l
+// under them, and one transversal element for each orbit point.
+type PermGroup struct {
+	n_ int
+
+	base_   []int
+	gens_   [][]Perm
+	orbits_ [][]int
+
+	// trans_[l][x] maps b_l to x; nil if x is not in the orbit.
+	trans_ [][]Perm
+}
+
+// Builds the group of permutations of n points generated by 'gens'.
+func NewPermGroup(n int, gens []Perm) *PermGroup {
+	g := &PermGroup{n_: n}
+
+	for _, s := range gens {
+		if !s.IsIdentity() {
+			g.addStrongGenerator(s, 0)
+		}
+	}
+	g.schreierSims()
+	return g
+}
+
+// Builds a group from a known base and the new generators found at each level. Generators of level l are
+// those of levels >= l. Used by subgroup searches, which already produce a strong generating set.
+func newPermGroupWithBase(n int, base []int, levelGens [][]Perm) *PermGroup {
+	g := &PermGroup{n_: n}
+	g.base_ = append(g.base_, base...)
+	g.gens_ = make([][]Perm, len(base))
+	g.orbits_ = make([][]int, len(base))
+	g.trans_ = make([][]Perm, len(base))
+
+	var cur []Perm
+	for l := len(base) - 1; l >= 0; l-- {
+		cur = append(cur, levelGens[l]...)
+		g.gens_[l] = append([]Perm(nil), cur...)
+		g.updateTransversal(l)
+	}
+	return g
+}
+
+// Number of points the group acts on
+func (g *PermGroup) Degree() int {
+	return g.n_
+}
+
+func (g *PermGroup) Base() []int {
+	return g.base_
+}
+
+// Strong generators of the whole group
+func (g *PermGroup) Generators() []Perm {
+	if len(g.gens_) == 0 {
+		return nil
+	}
+	return g.gens_[0]
+}
+
+// Exact group order: product of the basic orbit sizes.
+func (g *PermGroup) Order() *big.Int {
+	order := big.NewInt(1)
+	for _, orbit := range g.orbits_ {
+		order.Mul(order, big.NewInt(int64(len(orbit))))
+	}
+	return order
+}
+
+// Returns true if p is an element of the group
+func (g *PermGroup) Contains(p Perm) bool {
+	if len(p) != g.n_ {
+		return false
+	}
+	h, level := g.sift(p, 0)
+	return level == len(g.base_) && h.IsIdentity()
+}
+
+// Points reached from 'point' by the group
+func (g *PermGroup) Orbit(point int) []int {
+	return orbitOf(point, g.Generators())
+}
+
+// Divides p by transversal elements, level by level. Returns the residue and the level where it stopped
+// (len(base) if it went through all levels).
+func (g *PermGroup) sift(p Perm, start int) (Perm, int) {
+	h := p
+	for l := start; l < len(g.base_); l++ {
+		u := g.trans_[l][h[g.base_[l]]]
+		if u == nil {
+			return h, l
+		}
+		h = h.Mul(u.Inverse())
+	}
+	return h, len(g.base_)
+}
+
+// Adds s as strong generator of levels 'from' and deeper, while it fixes their base points. Extends the
+// base if s fixes all of them.
+func (g *PermGroup) addStrongGenerator(s Perm, from int) {
+	l := from
+	for ; l < len(g.base_); l++ {
+		g.gens_[l] = append(g.gens_[l], s)
+		g.updateTransversal(l)
+
+		if s[g.base_[l]] != g.base_[l] {
+			return
+		}
+	}
+
+	// s fixes all base points: new base point
+	for point, image := range s {
+		if point != image {
+			g.base_ = append(g.base_, point)
+			g.gens_ = append(g.gens_, []Perm{s})
+			g.orbits_ = append(g.orbits_, nil)
+			g.trans_ = append(g.trans_, nil)
+			g.updateTransversal(l)
+			return
+		}
+	}
+}
+
+// Recomputes orbit and transversal of level l from its generators.
+func (g *PermGroup) updateTransversal(l int) {
+	b := g.base_[l]
+	trans := make([]Perm, g.n_)
+	trans[b] = Identity(g.n_)
+	orbit := []int{b}
+
+	for i := 0; i < len(orbit); i++ {
+		x := orbit[i]
+		for _, s := range g.gens_[l] {
+			y := s[x]
+			if trans[y] == nil {
+				trans[y] = trans[x].Mul(s)
+				orbit = append(orbit, y)
+			}
+		}
+	}
+	g.orbits_[l] = orbit
+	g.trans_[l] = trans
+}
+
+// Deterministic Schreier-Sims: checks, from the deepest level up, that every Schreier generator of a level
+// sifts through the levels below. Residues become new strong generators and the check resumes there.
+func (g *PermGroup) schreierSims() {
+	i := len(g.base_) - 1
+	for i >= 0 {
+		residueLevel := -1
+
+	schreierGens:
+		for _, x := range g.orbits_[i] {
+			for _, s := range g.gens_[i] {
+				y := s[x]
+
+				// u_x * s * u_y^-1 fixes b_i
+				sg := g.trans_[i][x].Mul(s).Mul(g.trans_[i][y].Inverse())
+				if sg.IsIdentity() {
+					continue
+				}
+
+				h, j := g.sift(sg, i+1)
+				if j < len(g.base_) || !h.IsIdentity() {
+					g.addStrongGenerator(h, i+1)
+					residueLevel = j
+					break schreierGens
+				}
+			}
+		}
+
+		if residueLevel >= 0 {
+			// Levels from i+1 to the residue level changed: check them again
+			i = residueLevel
+			if i >= len(g.base_) {
+				i = len(g.base_) - 1
+			}
+		} else {
+			i--
+		}
+	}
+}
+
+// Orbit of a point under a set of generators
+func orbitOf(point int, gens []Perm) []int {
+	found := map[int]bool{point: true}
+	orbit := []int{point}
+	for i := 0; i < len(orbit); i++ {
+		for _, s := range gens {
+			y := s[orbit[i]]
+			if !found[y] {
+				found[y] = true
+				orbit = append(orbit, y)
+			}
+		}
+	}
+	return orbit
+}
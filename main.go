@@ -1,10 +1,42 @@
 package main
 
+import "flag"
+
 import "github.com/edgarweto/puzzlopia/puzzle-solvers/checks"
+
 //import "github.com/edgarweto/puzzlopia/puzzle-solvers/analysis"
 
 func main() {
 
+	flag.Parse()
+	if *loadFlag != "" {
+		loadCollection(*loadFlag)
+	}
+	if *listFlag {
+		listPuzzles()
+		return
+	}
+	if *serviceFlag != "" {
+		serveService(*serviceFlag)
+		return
+	}
+	if *webFlag != "" {
+		serveWeb(*webFlag)
+		return
+	}
+	if *farthestFlag != "" {
+		showFarthest(*farthestFlag)
+		return
+	}
+	if *showFlag != "" {
+		showSolution(*showFlag)
+		return
+	}
+	if *benchFlag {
+		runBenchmark()
+		return
+	}
+
 	//doingTests()
 
 	//checks.CheckPennant()
@@ -30,5 +62,9 @@ func main() {
 
 	//analysis.AnalyzeEngelSunMoon()
 	//analysis.AnalyzeEngelColorWheels()
+	//analysis.AnalyzeHungarianRings()
+	//analysis.AnalyzeEngelGroups()
+	//analysis.SolveEngelScrambles()
+	//analysis.BuildPatternDatabases()
 
 }
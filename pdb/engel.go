@@ -0,0 +1,111 @@
+package pdb
+
+import "errors"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/groups"
+
+// Abstraction of an Engel puzzle: kept pieces keep their value (alike groups of the game are respected),
+// all other pieces become alike to every other piece sharing their orbit (rectangles or triangles).
+type EngelAbstraction struct {
+	game_         *engel.EngelGame
+	pieceToValue_ *defs.PieceToValue
+	perms_        []groups.Perm
+}
+
+// Builds the abstraction from the game definition and the ids of the pieces kept distinct.
+func NewEngelAbstraction(g *engel.EngelGame, keep []int) *EngelAbstraction {
+	a := &EngelAbstraction{game_: g, pieceToValue_: defs.NewPieceToValue()}
+
+	// All powers of each wheel turn are single moves
+	for _, p := range g.WheelPermutations() {
+		q := p
+		for i := 1; i < 6; i++ {
+			a.perms_ = append(a.perms_, q)
+			q = q.Mul(p)
+		}
+	}
+
+	kept := make(map[int]bool)
+	for _, id := range keep {
+		kept[id] = true
+	}
+
+	current := g.SlotPieces(g.State().(*engel.EngelState))
+	maxValue := 0
+	for _, id := range current {
+		if v := g.PieceValue(id); v > maxValue {
+			maxValue = v
+		}
+	}
+
+	group := groups.NewPermGroup(len(current), g.WheelPermutations())
+	orbitValue := make(map[int]int)
+	for slot, id := range current {
+		if kept[id] {
+			a.pieceToValue_.Set(id, g.PieceValue(id))
+			continue
+		}
+		if _, ok := orbitValue[slot]; !ok {
+			maxValue++
+			for _, x := range group.Orbit(slot) {
+				orbitValue[x] = maxValue
+			}
+		}
+		a.pieceToValue_.Set(id, orbitValue[slot])
+	}
+	return a
+}
+
+// Abstract values, by slot, of an Engel state
+func (a *EngelAbstraction) coloring(s *engel.EngelState) []int {
+	c := a.game_.SlotPieces(s)
+	for i, id := range c {
+		c[i] = a.pieceToValue_.At(id)
+	}
+	return c
+}
+
+// Key of the abstract state
+func (a *EngelAbstraction) Key(s *engel.EngelState) string {
+	return valuesKey(a.coloring(s))
+}
+
+// Returns the heuristic value of a state: its abstract distance in wheel turns, or 0 if unknown.
+func (a *EngelAbstraction) Heuristic(db *PatternDB, s *engel.EngelState) int {
+	d, _ := db.Distance(a.Key(s))
+	return d
+}
+
+// Builds the pattern database of an Engel puzzle, with distances in wheel turns to the target state.
+// Turns are reversible, so a breadth first search from the target gives them. maxStates limits the
+// abstract space (0 is no limit).
+func BuildEngel(g *engel.EngelGame, target *engel.EngelState, keep []int, maxStates int) (*PatternDB, *EngelAbstraction, error) {
+	a := NewEngelAbstraction(g, keep)
+	db := newPatternDB("engel", keep, defs.TurnMetric())
+
+	root := a.coloring(target)
+	db.distances_[valuesKey(root)] = 0
+	frontier := [][]int{root}
+
+	for d := 1; len(frontier) > 0; d++ {
+		var next [][]int
+		for _, c := range frontier {
+			for _, p := range a.perms_ {
+				n := p.Apply(c)
+				k := valuesKey(n)
+				if _, ok := db.distances_[k]; !ok {
+					if maxStates > 0 && len(db.distances_) >= maxStates {
+						return nil, nil, errors.New("max states reached while building the database")
+					}
+					db.distances_[k] = d
+					db.maxDistance_ = d
+					next = append(next, n)
+				}
+			}
+		}
+		frontier = next
+	}
+	return db, a, nil
+}
@@ -0,0 +1,118 @@
+package pdb
+
+import "encoding/binary"
+import "encoding/gob"
+import "errors"
+import "os"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Pattern database: exact distances to the goal in an abstraction of a puzzle, where only some pieces
+// are kept distinct and the rest are alike. Distances in the abstraction never exceed real distances,
+// so they are admissible heuristics.
+//
+// States are looked up by the key their abstraction builds (see SBPAbstraction, EngelAbstraction).
+type PatternDB struct {
+	kind_        string
+	keep_        []int
+	metric_      defs.Metric
+	distances_   map[string]int
+	maxDistance_ int
+}
+
+// Persisted form of the database. The metric is saved by its name (see defs.MetricByName).
+type dbFile struct {
+	Kind        string
+	Keep        []int
+	Metric      string
+	Distances   map[string]int
+	MaxDistance int
+}
+
+func newPatternDB(kind string, keep []int, metric defs.Metric) *PatternDB {
+	db := &PatternDB{kind_: kind, metric_: metric}
+	db.keep_ = append(db.keep_, keep...)
+	db.distances_ = make(map[string]int)
+	return db
+}
+
+// Puzzle family of the abstraction: "sbp" or "engel"
+func (db *PatternDB) Kind() string {
+	return db.kind_
+}
+
+// Ids of the pieces kept distinct
+func (db *PatternDB) Keep() []int {
+	return db.keep_
+}
+
+// Metric of the distances
+func (db *PatternDB) Metric() defs.Metric {
+	return db.metric_
+}
+
+// Number of abstract states
+func (db *PatternDB) Size() int {
+	return len(db.distances_)
+}
+
+// Largest distance to the goal in the abstraction
+func (db *PatternDB) MaxDistance() int {
+	return db.maxDistance_
+}
+
+// Distance to the goal of an abstract state. Returns false if the abstract state is not in the database.
+func (db *PatternDB) Distance(key string) (int, bool) {
+	d, ok := db.distances_[key]
+	return d, ok
+}
+
+// Distance histogram: number of abstract states at each distance
+func (db *PatternDB) Histogram() []int {
+	h := make([]int, db.maxDistance_+1)
+	for _, d := range db.distances_ {
+		h[d]++
+	}
+	return h
+}
+
+// Saves the database to a file
+func (db *PatternDB) Save(path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return gob.NewEncoder(f).Encode(dbFile{db.kind_, db.keep_, db.metric_.Name(), db.distances_, db.maxDistance_})
+}
+
+// Loads a database saved with Save. The abstraction used for lookups must be built with the same kept pieces.
+func Load(path string) (*PatternDB, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var data dbFile
+	if err := gob.NewDecoder(f).Decode(&data); err != nil {
+		return nil, err
+	}
+	metric := defs.MetricByName(data.Metric)
+	if metric == nil {
+		return nil, errors.New("unknown metric " + data.Metric)
+	}
+	return &PatternDB{data.Kind, data.Keep, metric, data.Distances, data.MaxDistance}, nil
+}
+
+// Builds a compact key from abstract values
+func valuesKey(values []int) string {
+	buf := make([]byte, 0, len(values)*2)
+	tmp := make([]byte, binary.MaxVarintLen64)
+	for _, v := range values {
+		n := binary.PutUvarint(tmp, uint64(v))
+		buf = append(buf, tmp[:n]...)
+	}
+	return string(buf)
+}
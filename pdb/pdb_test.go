@@ -0,0 +1,144 @@
+package pdb
+
+import "os"
+import "path/filepath"
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Sliding blocks game with the given fixed pieces
+func sbGame(start grids.Matrix2d, fixed ...int) *games.SBGame {
+	var g games.SBGame
+	g.Define(&start)
+	for _, id := range fixed {
+		g.SetFixedPiece(id)
+	}
+	g.Build()
+	return &g
+}
+
+func TestSBPDatabase(t *testing.T) {
+	start := grids.Matrix2d{
+		[]int{1, 1, 0},
+		[]int{2, 3, 0},
+	}
+	goal := grids.Matrix2d{
+		[]int{0, 0, 0},
+		[]int{0, 1, 1},
+	}
+	g := sbGame(start)
+
+	steps, a, err := BuildSBP(g, &goal, []int{1}, defs.StepMetric(), 0)
+	if err != nil {
+		t.Fatalf("BuildSBP failed: %v", err)
+	}
+	moves, _, err := BuildSBP(g, &goal, []int{1}, defs.MoveMetric(), 0)
+	if err != nil {
+		t.Fatalf("BuildSBP failed: %v", err)
+	}
+
+	// Pieces 2 and 3 are alike in the abstraction
+	if a.Value(2) != a.Value(3) || a.Value(1) == a.Value(2) {
+		t.Errorf("Unexpected abstract values: %d %d %d", a.Value(1), a.Value(2), a.Value(3))
+	}
+
+	dSteps, ok1 := steps.Distance(a.Key(&start))
+	dMoves, ok2 := moves.Distance(a.Key(&start))
+	if !ok1 || !ok2 || dSteps < dMoves || dMoves == 0 {
+		t.Errorf("Unexpected start distances: %d steps, %d moves", dSteps, dMoves)
+	}
+
+	// Save and load
+	path := filepath.Join(t.TempDir(), "sbp.pdb")
+	if err := steps.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	defer os.Remove(path)
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if loaded.Size() != steps.Size() || loaded.MaxDistance() != steps.MaxDistance() || loaded.Kind() != "sbp" {
+		t.Errorf("Loaded database differs: %d/%d states", loaded.Size(), steps.Size())
+	}
+	if d, _ := loaded.Distance(a.Key(&start)); d != dSteps {
+		t.Errorf("Loaded distance %d, should be %d", d, dSteps)
+	}
+	if loaded.Metric().Name() != "step" {
+		t.Errorf("Loaded metric %s, should be step", loaded.Metric().Name())
+	}
+}
+
+// Fixed pieces never move, and are never alike to the other pieces
+func TestSBPFixedPieces(t *testing.T) {
+	start := grids.Matrix2d{
+		[]int{1, 2, 0},
+		[]int{0, 0, 0},
+	}
+	goal := grids.Matrix2d{
+		[]int{0, 0, 1},
+		[]int{0, 0, 0},
+	}
+
+	free, freeA, err := BuildSBP(sbGame(start), &goal, []int{1}, defs.StepMetric(), 0)
+	if err != nil {
+		t.Fatalf("BuildSBP failed: %v", err)
+	}
+	db, a, err := BuildSBP(sbGame(start, 2), &goal, []int{1}, defs.StepMetric(), 0)
+	if err != nil {
+		t.Fatalf("BuildSBP failed: %v", err)
+	}
+	if a.Value(2) == a.Value(1) || a.Value(2) == a.Value(0) {
+		t.Errorf("The fixed piece should keep its own value")
+	}
+
+	// Piece 2 moving down lets piece 1 through; fixed, piece 1 goes around it
+	if d, _ := free.Distance(freeA.Key(&start)); d != 3 {
+		t.Errorf("Start distance %d, should be 3", d)
+	}
+	if d, _ := db.Distance(a.Key(&start)); d != 4 {
+		t.Errorf("Start distance with a fixed piece %d, should be 4", d)
+	}
+	if db.Size() != 5 {
+		t.Errorf("%d abstract states with a fixed piece, should be 5", db.Size())
+	}
+}
+
+// Keeping all pieces of SUN-MOON, the database holds the whole puzzle: as many states as its group orbit.
+func TestEngelDatabase(t *testing.T) {
+	var g engel.EngelGame
+
+	g.Define([12]int{7, 6, 13, 14, 15, 16, 17, 18, 19, 20, 21, 8}, [12]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	g.DefineIntersectionPositions([3]int{11, 0, 1}, [3]int{7, 6, 5})
+	g.SetAlike([][]int{
+		[]int{1, 3, 5, 7, 9, 11},
+		[]int{2, 4, 6, 8, 10, 12},
+		[]int{13, 15, 17, 19, 21},
+		[]int{14, 16, 18, 20},
+	})
+
+	target := g.State().(*engel.EngelState)
+	db, _, err := BuildEngel(&g, target, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, 0)
+	if err != nil {
+		t.Fatalf("BuildEngel failed: %v", err)
+	}
+	if orbit := g.OrbitSize().Int64(); int64(db.Size()) != orbit {
+		t.Errorf("Database size %d, should be %d", db.Size(), orbit)
+	}
+	if db.Metric().Name() != "turn" {
+		t.Errorf("Distances in %s metric, should be turns", db.Metric().Name())
+	}
+
+	// Keeping fewer pieces: smaller abstraction, smaller distances
+	small, _, err := BuildEngel(&g, target, []int{1, 2}, 0)
+	if err != nil {
+		t.Fatalf("BuildEngel failed: %v", err)
+	}
+	if small.Size() >= db.Size() || small.MaxDistance() > db.MaxDistance() {
+		t.Errorf("Abstraction should be smaller: %d states, max distance %d", small.Size(), small.MaxDistance())
+	}
+}
@@ -0,0 +1,212 @@
+package pdb
+
+import "errors"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Abstraction of a sliding block puzzle: kept pieces stay distinct, all other pieces become alike to the
+// pieces with the same shape. The game settings are respected: fixed pieces never move, pieces marked as
+// not alike stay distinct, and pieces alike in the game stay alike. Uses its own piece-to-value map, so the
+// game is untouched.
+type SBPAbstraction struct {
+	pieces_       []*grids.GridPiece2
+	movable_      []*grids.GridPiece2
+	piecesById_   map[int]*grids.GridPiece2
+	pieceToValue_ *defs.PieceToValue
+}
+
+// Builds the abstraction from the game, at its current state, and the ids of the pieces kept distinct.
+func NewSBPAbstraction(g *games.SBGame, keep []int) *SBPAbstraction {
+	a := &SBPAbstraction{}
+
+	start := g.State().(*games.SBPState).Grid()
+	start.GeneratePieces(&a.pieces_, nil)
+	grids.DetectAlikePieces(a.pieces_, start.Max()+1)
+
+	// Pieces alike to a kept piece in the game are kept too
+	kept := make(map[int]bool)
+	for _, id := range keep {
+		kept[g.PieceValue(id)] = true
+	}
+
+	// Abstract value of each game value, so that pieces alike in the game stay alike
+	values := make(map[int]int)
+	for _, p := range a.pieces_ {
+		id := p.Id()
+		if kept[g.PieceValue(id)] || g.IsNotAlike(id) {
+			p.SetValue(g.PieceValue(id))
+		} else if v, ok := values[g.PieceValue(id)]; ok {
+			p.SetValue(v)
+		} else {
+			values[g.PieceValue(id)] = p.Value()
+		}
+	}
+
+	a.pieceToValue_ = defs.NewPieceToValue()
+	a.piecesById_ = make(map[int]*grids.GridPiece2)
+	for _, p := range a.pieces_ {
+		a.pieceToValue_.Set(p.Id(), p.Value())
+		a.piecesById_[p.Id()] = p
+		if !g.IsFixed(p.Id()) {
+			a.movable_ = append(a.movable_, p)
+		}
+	}
+	return a
+}
+
+// Abstract value of a piece id
+func (a *SBPAbstraction) Value(pieceId int) int {
+	return a.pieceToValue_.At(pieceId)
+}
+
+// Key of the abstract state of a grid
+func (a *SBPAbstraction) Key(m *grids.Matrix2d) string {
+	rows := m.Rows()
+	cols := m.Cols()
+
+	values := make([]int, 0, rows*cols)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			values = append(values, a.pieceToValue_.At(m.At(r, c)))
+		}
+	}
+	return valuesKey(values)
+}
+
+// Returns the heuristic value of a state: its abstract distance, or 0 if unknown.
+func (a *SBPAbstraction) Heuristic(db *PatternDB, s *games.SBPState) int {
+	d, _ := db.Distance(a.Key(s.Grid()))
+	return d
+}
+
+// True if the grid matches the goal template (non-zero goal cells) in the abstraction
+func (a *SBPAbstraction) isGoal(m *grids.Matrix2d, goal *grids.Matrix2d) bool {
+	for r := 0; r < goal.Rows(); r++ {
+		for c := 0; c < goal.Cols(); c++ {
+			if x := goal.At(r, c); x != 0 && a.pieceToValue_.At(m.At(r, c)) != a.pieceToValue_.At(x) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Grids reached from m in one move of the metric: a step, or a whole move of a piece.
+func (a *SBPAbstraction) successors(m *grids.Matrix2d, metric defs.Metric) []*grids.Matrix2d {
+	var next []*grids.Matrix2d
+
+	var work grids.Matrix2d
+	work.Copy(m)
+	work.UpdatePiecePositions(a.piecesById_)
+
+	for _, p := range a.movable_ {
+		origin := p.Position()
+
+		// Positions reached by the piece, breadth first. With the step metric, only the first ring.
+		visited := map[grids.Coords2]bool{origin: true}
+		pending := []grids.Coords2{origin}
+
+		for len(pending) > 0 {
+			pos := pending[0]
+			pending = pending[1:]
+			movePieceTo(&work, p, pos)
+
+			for _, mov := range work.PieceMovements(p) {
+				dRow, dCol := mov.Translation()
+				to := grids.Coords2{pos[0] + dRow, pos[1] + dCol}
+				if visited[to] {
+					continue
+				}
+				visited[to] = true
+
+				movePieceTo(&work, p, to)
+				var s grids.Matrix2d
+				s.Copy(&work)
+				next = append(next, &s)
+				movePieceTo(&work, p, pos)
+
+				if metric.Memory() == defs.PIECE_MEMORY {
+					pending = append(pending, to)
+				}
+			}
+		}
+		movePieceTo(&work, p, origin)
+	}
+	return next
+}
+
+func movePieceTo(m *grids.Matrix2d, p *grids.GridPiece2, pos grids.Coords2) {
+	if p.Position() == pos {
+		return
+	}
+	m.ClearPiece(p)
+	p.SetPosition(pos[0], pos[1])
+	m.PlacePiece(p)
+}
+
+// Builds the pattern database of a sliding block game on a square lattice, in the step or the move metric.
+// The abstract space reachable from the game state is enumerated first; then a breadth first search from
+// all the abstract states matching the goal template (as in SbpBfsFinder.Detect) computes the distances.
+// maxStates limits the abstract space (0 is no limit).
+func BuildSBP(g *games.SBGame, goal *grids.Matrix2d, keep []int, metric defs.Metric, maxStates int) (*PatternDB, *SBPAbstraction, error) {
+	if metric.Name() != defs.StepMetric().Name() && metric.Name() != defs.MoveMetric().Name() {
+		return nil, nil, errors.New("metric not supported: " + metric.Name())
+	}
+	if g.Lattice() != grids.SQUARE_LATTICE {
+		return nil, nil, errors.New("only square lattices are supported")
+	}
+
+	a := NewSBPAbstraction(g, keep)
+	db := newPatternDB("sbp", keep, metric)
+	start := g.State().(*games.SBPState).Grid()
+
+	// 1. Enumerate the abstract space, one representative grid per abstract state
+	index := map[string]int{a.Key(start): 0}
+	var reps []*grids.Matrix2d
+	var s grids.Matrix2d
+	s.Copy(start)
+	reps = append(reps, &s)
+
+	for i := 0; i < len(reps); i++ {
+		for _, n := range a.successors(reps[i], metric) {
+			k := a.Key(n)
+			if _, ok := index[k]; !ok {
+				if maxStates > 0 && len(reps) >= maxStates {
+					return nil, nil, errors.New("max states reached while enumerating the abstract space")
+				}
+				index[k] = len(reps)
+				reps = append(reps, n)
+			}
+		}
+	}
+
+	// 2. Retrograde BFS from the goal states. Moves are reversible, so predecessors are successors.
+	var frontier []*grids.Matrix2d
+	for _, m := range reps {
+		if a.isGoal(m, goal) {
+			db.distances_[a.Key(m)] = 0
+			frontier = append(frontier, m)
+		}
+	}
+	if len(frontier) == 0 {
+		return nil, nil, errors.New("goal not reachable in the abstract space")
+	}
+
+	for d := 1; len(frontier) > 0; d++ {
+		var next []*grids.Matrix2d
+		for _, m := range frontier {
+			for _, n := range a.successors(m, metric) {
+				k := a.Key(n)
+				if _, ok := db.distances_[k]; !ok {
+					db.distances_[k] = d
+					db.maxDistance_ = d
+					next = append(next, n)
+				}
+			}
+		}
+		frontier = next
+	}
+	return db, a, nil
+}
@@ -0,0 +1,59 @@
+package puzzles
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Engel's two-wheel puzzles. Both share the wheels geometry; they only differ in which pieces are alike.
+var engelWheels = &EngelWheels{
+	Left:  [12]int{7, 6, 13, 14, 15, 16, 17, 18, 19, 20, 21, 8},
+	Right: [12]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+
+	LeftIntersection:  [3]int{11, 0, 1},
+	RightIntersection: [3]int{7, 6, 5},
+}
+
+func init() {
+
+	Register(&Puzzle{
+		Name:   "SunMoon",
+		Kind:   ENGEL_PUZZLE,
+		Wheels: engelWheels,
+
+		Alike: [][]int{
+
+			// Right wheel is full colour, so there are two groups (rectangles and trias)
+			[]int{1, 3, 5, 7, 9, 11},
+			[]int{2, 4, 6, 8, 10, 12},
+
+			// Left wheel with remaining pieces:
+			[]int{13, 15, 17, 19, 21},
+			[]int{14, 16, 18, 20},
+		},
+
+		Metric:    defs.TurnMetric(),
+		MaxDepth:  30,
+		MaxStates: 100000,
+	})
+
+	Register(&Puzzle{
+		Name:   "ColorWheels",
+		Kind:   ENGEL_PUZZLE,
+		Wheels: engelWheels,
+
+		Alike: [][]int{
+
+			// The six rectangles
+			[]int{1, 5, 9, 13, 17, 21},
+
+			// Triangles in the same group
+			[]int{2, 4},
+			[]int{6, 8},
+			[]int{10, 12},
+			[]int{14, 16},
+			[]int{18, 20},
+		},
+
+		Metric:    defs.TurnMetric(),
+		MaxDepth:  30,
+		MaxStates: 60000000,
+	})
+}
@@ -0,0 +1,526 @@
+package puzzles
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Sliding blocks puzzles. Optimal lengths use the move metric.
+func init() {
+
+	// Result: not found, the search reaches the max states limit
+	Register(&Puzzle{
+		Name: "AdelaaR",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{2, 1, 1, 1, 3},
+			[]int{2, 1, 1, 1, 3},
+			[]int{4, 4, 0, 5, 5},
+			[]int{6, 6, 0, 7, 7},
+			[]int{8, 9, 0, 10, 11},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0, 0},
+			[]int{0, 0, 0, 0, 0},
+			[]int{0, 0, 0, 0, 0},
+			[]int{0, 1, 1, 1, 0},
+			[]int{0, 1, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   0,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  300,
+		MaxStates: 1999999,
+		Pending:   true,
+	})
+
+	// Ane Rouge = Klotski
+	// Result: 81
+	// Should be: 81
+	Register(&Puzzle{
+		Name: "AneRouge",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{2, 1, 1, 3},
+			[]int{2, 1, 1, 3},
+			[]int{4, 5, 5, 6},
+			[]int{4, 8, 9, 6},
+			[]int{7, 0, 0, 10},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   81,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  300,
+		MaxStates: 99999,
+	})
+
+	// Result: 52
+	// Should be: 52
+	Register(&Puzzle{
+		Name: "ChrisEye",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{0, 2, 2, 0},
+			[]int{3, 7, 8, 4},
+			[]int{3, 1, 1, 4},
+			[]int{9, 1, 1, 10},
+			[]int{5, 5, 6, 6},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   52,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+	})
+
+	// Result: 99
+	// Should be: 99
+	Register(&Puzzle{
+		Name: "ChrisHeart",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{9, 1, 1, 10},
+			[]int{2, 1, 1, 3},
+			[]int{2, 4, 4, 3},
+			[]int{5, 5, 6, 6},
+			[]int{7, 0, 0, 8},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   99,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+	})
+
+	// Result: 89
+	// Should be: 89
+	// Currently finds a solution of 67 moves, but all pages refer to optimal solution of 200. May the puzzle config be wrong? Or it is the puzzle objective?
+	Register(&Puzzle{
+		Name: "ChrisIce",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{1, 1, 7, 8},
+			[]int{1, 1, 6, 8},
+			[]int{2, 2, 6, 5},
+			[]int{3, 4, 4, 5},
+			[]int{3, 0, 0, 0},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: false,
+
+		Optimal:   89,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 99999,
+	})
+
+	// Result: 109
+	// Should be: 109
+	Register(&Puzzle{
+		Name: "ChrisIris",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{0, 2, 10, 0},
+			[]int{3, 2, 1, 1},
+			[]int{3, 9, 1, 1},
+			[]int{4, 5, 5, 7},
+			[]int{4, 8, 6, 6},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   109,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+	})
+
+	// Result: 105
+	// Should be: 104
+	Register(&Puzzle{
+		Name: "ChrisLightning",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{0, 1, 1, 0},
+			[]int{2, 1, 1, 10},
+			[]int{2, 9, 3, 3},
+			[]int{4, 4, 7, 6},
+			[]int{8, 5, 5, 6},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   104,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+		Pending:   true,
+	})
+
+	// Result: 53
+	// Should be: 53
+	Register(&Puzzle{
+		Name: "ChrisMoon",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{0, 1, 1, 0},
+			[]int{8, 1, 1, 0},
+			[]int{2, 2, 5, 6},
+			[]int{3, 4, 5, 6},
+			[]int{3, 4, 7, 7},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   53,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+	})
+
+	// Result: 72
+	// Should be: 72
+	// Currently finds a solution of 67 moves, but all pages refer to optimal solution of 200. May the puzzle config be wrong? Or it is the puzzle objective?
+	Register(&Puzzle{
+		Name: "ChrisPacmen",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{1, 1, 8, 5},
+			[]int{1, 1, 4, 5},
+			[]int{0, 3, 4, 0},
+			[]int{2, 3, 7, 7},
+			[]int{2, 6, 6, 0},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   72,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+	})
+
+	// Result: 108
+	// Should be: 106
+	// Currently finds a solution of 67 moves, but all pages refer to optimal solution of 200. May the puzzle config be wrong? Or it is the puzzle objective?
+	Register(&Puzzle{
+		Name: "ChrisSkull",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{0, 1, 1, 0},
+			[]int{2, 1, 1, 6},
+			[]int{2, 3, 3, 6},
+			[]int{4, 4, 5, 5},
+			[]int{7, 8, 9, 10},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   106,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+		Pending:   true,
+	})
+
+	// Result: 91
+	// Should be: 91
+	// Currently finds a solution of 67 moves, but all pages refer to optimal solution of 200. May the puzzle config be wrong? Or it is the puzzle objective?
+	Register(&Puzzle{
+		Name: "ChrisStar",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{0, 1, 1, 0},
+			[]int{7, 1, 1, 6},
+			[]int{2, 9, 10, 6},
+			[]int{2, 3, 4, 4},
+			[]int{8, 3, 5, 5},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   91,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+	})
+
+	// Result: 90
+	// Should be: 90
+	// Currently finds a solution of 67 moves, but all pages refer to optimal solution of 200. May the puzzle config be wrong? Or it is the puzzle objective?
+	Register(&Puzzle{
+		Name: "ChrisSun",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{1, 1, 0, 0},
+			[]int{1, 1, 6, 10},
+			[]int{2, 8, 6, 5},
+			[]int{2, 3, 3, 5},
+			[]int{9, 4, 4, 7},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   90,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 250000,
+	})
+
+	// Result: 67
+	// Should be: 200
+	// Currently finds a solution of 67 moves, but all pages refer to optimal solution of 200. May the puzzle config be wrong? Or it is the puzzle objective?
+	Register(&Puzzle{
+		Name: "HIFI",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{0, 1, 1, 0},
+			[]int{9, 1, 1, 10},
+			[]int{2, 3, 3, 6},
+			[]int{2, 4, 4, 6},
+			[]int{7, 5, 5, 8},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 0, 0, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   200,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  300,
+		MaxStates: 1999999,
+		Pending:   true,
+	})
+
+	// Result: 59
+	// Should be: 59
+	Register(&Puzzle{
+		Name:   "Pennant",
+		Source: "http://www.puzzlopia.com/puzzles/pennant/play",
+		Kind:   SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{2, 2, 1, 1},
+			[]int{2, 2, 3, 3},
+			[]int{5, 4, 0, 0},
+			[]int{6, 7, 8, 8},
+			[]int{6, 7, 9, 9},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{2, 2, 0, 0},
+			[]int{2, 2, 0, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   59,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  300,
+		MaxStates: 1999999,
+	})
+
+	// Result: 84
+	// Should be: 84
+	Register(&Puzzle{
+		Name: "Quzzle",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{1, 1, 2, 2},
+			[]int{1, 1, 3, 4},
+			[]int{0, 0, 3, 4},
+			[]int{5, 6, 6, 8},
+			[]int{5, 7, 7, 9},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 1, 1},
+			[]int{0, 0, 1, 1},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   84,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  300,
+		MaxStates: 1999999,
+	})
+
+	// Result: 61
+	// Should be: unknown
+	Register(&Puzzle{
+		Name:   "RedditQuest4hj6nb",
+		Source: "https://www.reddit.com/r/puzzles/comments/4hj6nb/has_anyone_any_info_on_this_sliding_tile_puzzle/",
+		Kind:   SBP_PUZZLE,
+
+		// From reddit https://www.reddit.com/r/puzzles/comments/4hj6nb/has_anyone_any_info_on_this_sliding_tile_puzzle/
+		Start: grids.Matrix2d{
+			[]int{0, 1, 1, 0},
+			[]int{2, 1, 1, 4},
+			[]int{2, 7, 9, 4},
+			[]int{3, 8, 10, 5},
+			[]int{3, 6, 6, 5},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   0,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 1999999,
+	})
+
+	// Result: 140
+	// Should be: 138
+	Register(&Puzzle{
+		Name: "SuperCentury",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{2, 8, 9, 10},
+			[]int{2, 4, 1, 1},
+			[]int{3, 4, 1, 1},
+			[]int{3, 5, 5, 7},
+			[]int{0, 0, 6, 6},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   138,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 999999,
+		Pending:   true,
+	})
+
+	// Result: 123
+	// Should be: 123
+	Register(&Puzzle{
+		Name: "SuperCompo",
+		Kind: SBP_PUZZLE,
+
+		Start: grids.Matrix2d{
+			[]int{0, 1, 1, 0},
+			[]int{9, 1, 1, 10},
+			[]int{2, 3, 3, 6},
+			[]int{2, 4, 4, 6},
+			[]int{7, 5, 5, 8},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 0, 0, 0},
+			[]int{0, 1, 1, 0},
+			[]int{0, 1, 1, 0},
+		},
+		AutoAlike: true,
+
+		Optimal:   123,
+		Metric:    defs.MoveMetric(),
+		MaxDepth:  200,
+		MaxStates: 1999999,
+	})
+}
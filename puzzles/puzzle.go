@@ -0,0 +1,123 @@
+package puzzles
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Puzzle types
+const (
+	SBP_PUZZLE = iota
+	ENGEL_PUZZLE
+)
+
+// A puzzle of the catalog: what it is, where it comes from, how to build it and its known optimal solution.
+type Puzzle struct {
+	Name   string
+	Author string
+	Source string
+
+	Kind int
+
+	// SBP: start and goal (non-zero cells must match) matrices
+	Start grids.Matrix2d
+	Goal  grids.Matrix2d
+
+	// SBP: shape of the cells, square if not set
+	Lattice grids.Lattice
+
+	// SBP: replaces the goal matrix when the goal is not a template (see games.PieceAt, games.Region...)
+	GoalPredicate defs.Goal
+
+	// Engel: wheels definition
+	Wheels *EngelWheels
+
+	// Marks all pieces with same shape as alike (SBP only), except the 'NotAlike' ones
+	AutoAlike bool
+	NotAlike  []int
+
+	// Groups of interchangeable pieces
+	Alike [][]int
+
+	// Pieces that never move, like walls (SBP only)
+	Fixed []int
+
+	// Known optimal length, 0 if unknown, and the metric it is measured with
+	Optimal int
+	Metric  defs.Metric
+
+	// Finder limits that are known to be enough
+	MaxDepth  int
+	MaxStates int
+
+	// The finder does not find the optimal solution yet, or the puzzle does not fit in memory
+	Pending bool
+}
+
+// Piece ids of each Engel wheel (see engel.EngelGame.Define) and their intersection positions
+type EngelWheels struct {
+	Left  [12]int
+	Right [12]int
+
+	LeftIntersection  [3]int
+	RightIntersection [3]int
+}
+
+// Builds the sliding blocks game of the puzzle
+func (p *Puzzle) NewSBGame() *games.SBGame {
+	return p.NewSBGameOn(games.AUTO_BOARD)
+}
+
+// Same as NewSBGame, with the board representation of the states (see SBGame.SetBoardKind)
+func (p *Puzzle) NewSBGameOn(kind games.BoardKind) *games.SBGame {
+	if p.Kind != SBP_PUZZLE {
+		panic("[Puzzle::NewSBGameOn] " + p.Name + " is not a sliding blocks puzzle")
+	}
+
+	var g = &games.SBGame{}
+	g.SetLattice(p.Lattice)
+
+	// Define copies the matrix, so the catalog stays untouched
+	g.Define(&p.Start)
+	if p.AutoAlike {
+		g.AutoAlikePieces()
+	}
+	if len(p.Alike) > 0 {
+		g.AlikePieces(p.Alike)
+	}
+	for _, id := range p.NotAlike {
+		g.SetNotAlikePiece(id)
+	}
+	for _, id := range p.Fixed {
+		g.SetFixedPiece(id)
+	}
+	g.SetBoardKind(kind)
+	g.Build()
+
+	return g
+}
+
+// Goal of the sliding blocks puzzle: its predicate, or else its goal template
+func (p *Puzzle) Target() defs.Goal {
+	if p.GoalPredicate != nil {
+		return p.GoalPredicate
+	}
+	return games.Template(&p.Goal)
+}
+
+// Builds the Engel game of the puzzle
+func (p *Puzzle) NewEngelGame() *engel.EngelGame {
+	if p.Kind != ENGEL_PUZZLE || p.Wheels == nil {
+		panic("[Puzzle::NewEngelGame] " + p.Name + " is not an Engel puzzle")
+	}
+
+	var g = &engel.EngelGame{}
+
+	g.Define(p.Wheels.Left, p.Wheels.Right)
+	g.DefineIntersectionPositions(p.Wheels.LeftIntersection, p.Wheels.RightIntersection)
+	if len(p.Alike) > 0 {
+		g.SetAlike(p.Alike)
+	}
+
+	return g
+}
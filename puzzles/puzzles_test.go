@@ -0,0 +1,62 @@
+package puzzles
+
+import "testing"
+
+// Every puzzle of the catalog can be looked up and built.
+func TestCatalog(t *testing.T) {
+	if len(All()) == 0 {
+		t.Fatal("Empty catalog")
+	}
+
+	for _, p := range All() {
+		if Lookup(p.Name) != p {
+			t.Errorf("%s: lookup returns another puzzle", p.Name)
+		}
+
+		switch p.Kind {
+		case SBP_PUZZLE:
+			g := p.NewSBGame()
+			if g.State() == nil {
+				t.Errorf("%s: no initial state", p.Name)
+			}
+			if p.Start.Rows() != p.Goal.Rows() || p.Start.Cols() != p.Goal.Cols() {
+				t.Errorf("%s: start and goal sizes differ", p.Name)
+			}
+		case ENGEL_PUZZLE:
+			g := p.NewEngelGame()
+			if g.OrbitSize().Sign() <= 0 {
+				t.Errorf("%s: empty state space", p.Name)
+			}
+		default:
+			t.Errorf("%s: unknown kind %d", p.Name, p.Kind)
+		}
+	}
+
+	if Lookup("NotAPuzzle") != nil {
+		t.Error("Lookup of an unknown name should be nil")
+	}
+}
+
+func TestRegisterDuplicated(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Registering a duplicated name should panic")
+		}
+	}()
+	Register(&Puzzle{Name: "Pennant", Kind: SBP_PUZZLE})
+}
+
+// Building a game does not modify the catalog matrices
+func TestCatalogUntouched(t *testing.T) {
+	p := Lookup("Pennant")
+	before := p.Start.At(2, 2)
+
+	g := p.NewSBGame()
+	for _, m := range g.ValidMovementsBFS(nil) {
+		g.Move(m)
+		break
+	}
+	if p.Start.At(2, 2) != before {
+		t.Error("Catalog start matrix changed by a game move")
+	}
+}
@@ -0,0 +1,39 @@
+package puzzles
+
+// Registered puzzles, in registration order
+var s_puzzles_ []*Puzzle
+var s_byName_ = make(map[string]*Puzzle)
+
+// Adds a puzzle to the catalog. Names must be unique.
+func Register(p *Puzzle) {
+	if p.Name == "" {
+		panic("[puzzles::Register] puzzle without name")
+	}
+	if _, ok := s_byName_[p.Name]; ok {
+		panic("[puzzles::Register] duplicated puzzle name " + p.Name)
+	}
+
+	s_puzzles_ = append(s_puzzles_, p)
+	s_byName_[p.Name] = p
+}
+
+// Returns the registered puzzle with that name, or nil
+func Lookup(name string) *Puzzle {
+	return s_byName_[name]
+}
+
+// All the registered puzzles
+func All() []*Puzzle {
+	return append([]*Puzzle{}, s_puzzles_...)
+}
+
+// Registered puzzles of one kind (SBP_PUZZLE, ENGEL_PUZZLE). Pending puzzles are included only if asked.
+func ByKind(kind int, withPending bool) []*Puzzle {
+	var res []*Puzzle
+	for _, p := range s_puzzles_ {
+		if p.Kind == kind && (withPending || !p.Pending) {
+			res = append(res, p)
+		}
+	}
+	return res
+}
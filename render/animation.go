@@ -0,0 +1,84 @@
+package render
+
+import "fmt"
+import "io"
+import "time"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Clears the terminal and moves the cursor home
+const CLEAR_SCREEN = "\033[H\033[2J"
+
+// Boards after each step of the path, starting with the initial board.
+func Frames(start *grids.Matrix2d, path []defs.Command) []*grids.Matrix2d {
+	var m grids.Matrix2d
+	m.Copy(start)
+
+	frames := []*grids.Matrix2d{clone(start)}
+	for _, c := range path {
+		mov, ok := c.(*grids.GridMov2)
+		if !ok {
+			panic("[render::Frames] command is not a GridMov2")
+		}
+		m.ApplyRawTranslation(mov.PieceId(), *mov)
+		frames = append(frames, clone(&m))
+	}
+	return frames
+}
+
+// Draws the solution step by step, waiting 'delay' between steps. If 'clear', the terminal is
+// cleared before each board, so the board is animated in place.
+func Animate(w io.Writer, start *grids.Matrix2d, path []defs.Command, delay time.Duration, clear bool, opts Options) {
+	frames := Frames(start, path)
+
+	moves := 0
+	for i, m := range frames {
+		if clear {
+			fmt.Fprint(w, CLEAR_SCREEN)
+		}
+
+		if i == 0 {
+			fmt.Fprintf(w, "Start\n")
+		} else {
+			mov := path[i-1]
+			if i == 1 || path[i-2].PieceId() != mov.PieceId() {
+				moves++
+			}
+			dRow, dCol := mov.(*grids.GridMov2).Translation()
+			fmt.Fprintf(w, "Step %d/%d, move %d: piece %d %s\n", i, len(path), moves, mov.PieceId(), direction(dRow, dCol))
+		}
+		fmt.Fprint(w, Board(m, opts))
+
+		if delay > 0 && i < len(frames)-1 {
+			time.Sleep(delay)
+		}
+	}
+}
+
+func clone(m *grids.Matrix2d) *grids.Matrix2d {
+	var c grids.Matrix2d
+	c.Copy(m)
+	return &c
+}
+
+// Hex and triangle lattices have diagonal steps, like "up-right"
+func direction(dRow int, dCol int) string {
+	vertical, horizontal := "", "right"
+	switch {
+	case dRow < 0:
+		vertical = "up"
+	case dRow > 0:
+		vertical = "down"
+	}
+	switch {
+	case dCol < 0:
+		horizontal = "left"
+	case dCol == 0:
+		return vertical
+	}
+	if vertical == "" {
+		return horizontal
+	}
+	return vertical + "-" + horizontal
+}
@@ -0,0 +1,187 @@
+package render
+
+import "strconv"
+import "strings"
+
+import "github.com/fatih/color"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// How boards are drawn
+type Options struct {
+
+	// Plain ASCII borders (+, -, |) instead of box-drawing characters
+	ASCII bool
+
+	// Fills each piece with its own background color
+	Color bool
+
+	// Shape of the cells: hex and triangle boards show the piece id on every cell ('.' if empty), as
+	// borders between their cells do not fit in text
+	Lattice grids.Lattice
+}
+
+// Border segments meeting at a junction
+const (
+	UP = 1 << iota
+	RIGHT
+	DOWN
+	LEFT
+)
+
+// Box-drawing junctions, indexed by segments mask
+var s_boxJunctions_ = []rune{
+	' ', '╵', '╶', '└', '╷', '│', '┌', '├', '╴', '┘', '─', '┴', '┐', '┤', '┬', '┼',
+}
+
+// Piece background colors, cycled by piece id
+var s_palette_ = []color.Attribute{
+	color.BgRed, color.BgGreen, color.BgYellow, color.BgBlue, color.BgMagenta, color.BgCyan,
+	color.BgHiRed, color.BgHiGreen, color.BgHiYellow, color.BgHiBlue, color.BgHiMagenta, color.BgHiCyan,
+}
+
+// Draws the board: borders separate different pieces (and pieces from empty cells), and each piece
+// shows its id on its first cell.
+func Board(m *grids.Matrix2d, opts Options) string {
+	switch opts.Lattice {
+	case grids.HEX_LATTICE:
+		return hexBoard(m, opts)
+	case grids.TRIANGLE_LATTICE:
+		return triangleBoard(m, opts)
+	}
+
+	b := boardDrawer{m_: m, opts_: opts}
+	return b.draw()
+}
+
+type boardDrawer struct {
+	m_     *grids.Matrix2d
+	opts_  Options
+	width_ int
+}
+
+// Cell value, -1 outside the board
+func (b *boardDrawer) at(row int, col int) int {
+	if row < 0 || row >= b.m_.Rows() || col < 0 || col >= b.m_.Cols() {
+		return -1
+	}
+	return b.m_.At(row, col)
+}
+
+func (b *boardDrawer) draw() string {
+	rows := b.m_.Rows()
+	cols := b.m_.Cols()
+
+	b.width_ = len(strconv.Itoa(b.m_.Max())) + 2
+	if b.width_ < 3 {
+		b.width_ = 3
+	}
+
+	labeled := make(map[int]bool)
+
+	var out strings.Builder
+	for r := 0; r <= rows; r++ {
+
+		// Border line over row r
+		for c := 0; c <= cols; c++ {
+			out.WriteString(b.junction(r, c))
+			if c < cols {
+				up, down := b.at(r-1, c), b.at(r, c)
+				if up != down {
+					out.WriteString(strings.Repeat(b.horizontal(), b.width_))
+				} else {
+					out.WriteString(b.fill(strings.Repeat(" ", b.width_), up))
+				}
+			}
+		}
+		out.WriteString("\n")
+
+		if r == rows {
+			break
+		}
+
+		// Cells of row r
+		for c := 0; c <= cols; c++ {
+			left, right := b.at(r, c-1), b.at(r, c)
+			if left != right {
+				out.WriteString(b.vertical())
+			} else {
+				out.WriteString(b.fill(" ", left))
+			}
+			if c < cols {
+				out.WriteString(b.cell(right, labeled))
+			}
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// Junction at the top-left corner of cell (row, col)
+func (b *boardDrawer) junction(row int, col int) string {
+	tl, tr := b.at(row-1, col-1), b.at(row-1, col)
+	bl, br := b.at(row, col-1), b.at(row, col)
+
+	mask := 0
+	if tl != tr {
+		mask |= UP
+	}
+	if tr != br {
+		mask |= RIGHT
+	}
+	if bl != br {
+		mask |= DOWN
+	}
+	if tl != bl {
+		mask |= LEFT
+	}
+
+	if mask == 0 {
+		return b.fill(" ", tl)
+	}
+	if !b.opts_.ASCII {
+		return string(s_boxJunctions_[mask])
+	}
+	switch mask {
+	case UP, DOWN, UP | DOWN:
+		return "|"
+	case LEFT, RIGHT, LEFT | RIGHT:
+		return "-"
+	}
+	return "+"
+}
+
+func (b *boardDrawer) horizontal() string {
+	if b.opts_.ASCII {
+		return "-"
+	}
+	return "─"
+}
+
+func (b *boardDrawer) vertical() string {
+	if b.opts_.ASCII {
+		return "|"
+	}
+	return "│"
+}
+
+// Cell contents: the piece id on the first cell of each piece
+func (b *boardDrawer) cell(id int, labeled map[int]bool) string {
+	s := strings.Repeat(" ", b.width_)
+	if id > 0 && !labeled[id] {
+		labeled[id] = true
+
+		label := strconv.Itoa(id)
+		pad := (b.width_ - len(label)) / 2
+		s = strings.Repeat(" ", pad) + label + strings.Repeat(" ", b.width_-pad-len(label))
+	}
+	return b.fill(s, id)
+}
+
+// Colors the text with the piece color, if enabled and it is a piece
+func (b *boardDrawer) fill(s string, id int) string {
+	if !b.opts_.Color || id <= 0 {
+		return s
+	}
+	return color.New(s_palette_[id%len(s_palette_)], color.FgBlack).Sprint(s)
+}
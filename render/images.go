@@ -0,0 +1,202 @@
+package render
+
+import "image"
+import "image/color"
+import "image/draw"
+import "image/gif"
+import "io"
+import "strconv"
+import "time"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// How images are drawn
+type ImageStyle struct {
+
+	// Pixels of a board cell, or of an Engel wheel radius
+	CellSize int
+
+	// Piece ids drawn on pieces (SVG only)
+	Labels bool
+
+	// Piece values: alike pieces get the same color. If nil, each piece id has its own color.
+	Values *defs.PieceToValue
+}
+
+// Default style: 40 pixels per cell, with labels
+func DefaultImageStyle() ImageStyle {
+	return ImageStyle{CellSize: 40, Labels: true}
+}
+
+var (
+	s_background_ = color.RGBA{0xf4, 0xf1, 0xea, 0xff}
+	s_emptyCell_  = color.RGBA{0xdd, 0xd8, 0xcc, 0xff}
+	s_border_     = color.RGBA{0x33, 0x33, 0x33, 0xff}
+
+	// Piece colors, cycled by piece value
+	s_pieceColors_ = []color.RGBA{
+		{0xe6, 0x4b, 0x3c, 0xff}, {0x3c, 0x8d, 0xe6, 0xff}, {0xf2, 0xc1, 0x2e, 0xff}, {0x4c, 0xaf, 0x50, 0xff},
+		{0x9c, 0x59, 0xb6, 0xff}, {0xe6, 0x7e, 0x22, 0xff}, {0x1a, 0xbc, 0x9c, 0xff}, {0xe8, 0x43, 0x93, 0xff},
+		{0x8d, 0x6e, 0x63, 0xff}, {0x60, 0x7d, 0x8b, 0xff}, {0xcd, 0xdc, 0x39, 0xff}, {0x00, 0x96, 0x88, 0xff},
+		{0x3f, 0x51, 0xb5, 0xff}, {0xff, 0x98, 0x00, 0xff}, {0x79, 0x55, 0x48, 0xff}, {0xaa, 0xaa, 0xaa, 0xff},
+	}
+)
+
+func (st *ImageStyle) cellSize() float64 {
+	if st.CellSize <= 0 {
+		return 40
+	}
+	return float64(st.CellSize)
+}
+
+func (st *ImageStyle) pieceColor(id int) color.RGBA {
+	if id <= 0 {
+		return s_emptyCell_
+	}
+	v := id
+	if st.Values != nil {
+		v = st.Values.At(id)
+	}
+	return s_pieceColors_[v%len(s_pieceColors_)]
+}
+
+// Scene of a board: colored cells, and borders around pieces
+func boardScene(m *grids.Matrix2d, st ImageStyle) *scene {
+	cs := st.cellSize()
+	margin := cs / 4
+	stroke := cs / 12
+	rows, cols := m.Rows(), m.Cols()
+
+	s := newScene(float64(cols)*cs+2*margin, float64(rows)*cs+2*margin)
+	s.rect(0, 0, s.width_, s.height_, s_background_)
+
+	at := func(r int, c int) int {
+		if r < 0 || r >= rows || c < 0 || c >= cols {
+			return -1
+		}
+		return m.At(r, c)
+	}
+
+	labeled := make(map[int]bool)
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			s.rect(margin+float64(c)*cs, margin+float64(r)*cs, cs, cs, st.pieceColor(m.At(r, c)))
+		}
+	}
+
+	// Borders between different pieces
+	for r := 0; r <= rows; r++ {
+		for c := 0; c <= cols; c++ {
+			x, y := margin+float64(c)*cs, margin+float64(r)*cs
+			if c < cols && at(r-1, c) != at(r, c) {
+				s.line(x, y, x+cs, y, stroke, s_border_)
+			}
+			if r < rows && at(r, c-1) != at(r, c) {
+				s.line(x, y, x, y+cs, stroke, s_border_)
+			}
+		}
+	}
+
+	if st.Labels {
+		for r := 0; r < rows; r++ {
+			for c := 0; c < cols; c++ {
+				id := m.At(r, c)
+				if id > 0 && !labeled[id] {
+					labeled[id] = true
+					s.text(margin+(float64(c)+0.5)*cs, margin+(float64(r)+0.5)*cs, cs/2.5, strconv.Itoa(id), s_border_)
+				}
+			}
+		}
+	}
+	return s
+}
+
+// SVG drawing of a board
+func BoardSVG(m *grids.Matrix2d, st ImageStyle) string {
+	return boardScene(m, st).svg()
+}
+
+// Image of a board, to be encoded with image/png. Labels are not drawn.
+func BoardImage(m *grids.Matrix2d, st ImageStyle) *image.RGBA {
+	return boardScene(m, st).image()
+}
+
+// Boards after each move of the path (consecutive steps of the same piece are one move),
+// starting with the initial board.
+func MoveFrames(start *grids.Matrix2d, path []defs.Command) []*grids.Matrix2d {
+	steps := Frames(start, path)
+
+	frames := []*grids.Matrix2d{steps[0]}
+	for i := range path {
+		if i == len(path)-1 || path[i+1].PieceId() != path[i].PieceId() {
+			frames = append(frames, steps[i+1])
+		}
+	}
+	return frames
+}
+
+// All the boards side by side, 'perRow' boards per row.
+func filmstripScene(frames []*scene, perRow int) *scene {
+	if len(frames) == 0 {
+		return newScene(0, 0)
+	}
+	if perRow <= 0 || perRow > len(frames) {
+		perRow = len(frames)
+	}
+	w, h := frames[0].width_, frames[0].height_
+	numRows := (len(frames) + perRow - 1) / perRow
+
+	s := newScene(w*float64(perRow), h*float64(numRows))
+	s.rect(0, 0, s.width_, s.height_, s_background_)
+	for i, f := range frames {
+		s.add(f, w*float64(i%perRow), h*float64(i/perRow))
+	}
+	return s
+}
+
+func boardScenes(frames []*grids.Matrix2d, st ImageStyle) []*scene {
+	var scenes []*scene
+	for _, m := range frames {
+		scenes = append(scenes, boardScene(m, st))
+	}
+	return scenes
+}
+
+// SVG filmstrip of a sequence of boards (see MoveFrames)
+func FilmstripSVG(frames []*grids.Matrix2d, st ImageStyle, perRow int) string {
+	return filmstripScene(boardScenes(frames, st), perRow).svg()
+}
+
+// Filmstrip image of a sequence of boards (see MoveFrames)
+func FilmstripImage(frames []*grids.Matrix2d, st ImageStyle, perRow int) *image.RGBA {
+	return filmstripScene(boardScenes(frames, st), perRow).image()
+}
+
+// Writes an animated GIF of a sequence of boards, 'delay' between them.
+func BoardsGIF(w io.Writer, frames []*grids.Matrix2d, st ImageStyle, delay time.Duration) error {
+	return writeGIF(w, boardScenes(frames, st), delay)
+}
+
+func writeGIF(w io.Writer, scenes []*scene, delay time.Duration) error {
+	palette := color.Palette{s_background_, s_emptyCell_, s_border_}
+	for _, c := range s_pieceColors_ {
+		palette = append(palette, c)
+	}
+
+	anim := &gif.GIF{}
+	for i, s := range scenes {
+		img := s.image()
+		frame := image.NewPaletted(img.Bounds(), palette)
+		draw.Draw(frame, img.Bounds(), img, image.Point{}, draw.Src)
+
+		// Last frame stays longer
+		d := int(delay / (10 * time.Millisecond))
+		if i == len(scenes)-1 {
+			d *= 4
+		}
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, d)
+	}
+	return gif.EncodeAll(w, anim)
+}
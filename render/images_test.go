@@ -0,0 +1,111 @@
+package render
+
+import "bytes"
+import "image/gif"
+import "strings"
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+func TestBoardImages(t *testing.T) {
+	m := grids.Matrix2d{
+		[]int{1, 1, 2},
+		[]int{1, 0, 0},
+	}
+	st := ImageStyle{CellSize: 10, Labels: true}
+
+	svg := BoardSVG(&m, st)
+	if n := strings.Count(svg, "<rect"); n != 7 {
+		t.Errorf("SVG should have a background and 6 cells, has %d rects", n)
+	}
+	if !strings.Contains(svg, ">1</text>") || !strings.Contains(svg, ">2</text>") || strings.Contains(svg, ">0</text>") {
+		t.Errorf("Wrong SVG labels:\n%s", svg)
+	}
+
+	// 3x2 cells of 10 pixels, and 2.5 pixels of margin
+	img := BoardImage(&m, st)
+	if b := img.Bounds(); b.Dx() != 35 || b.Dy() != 25 {
+		t.Errorf("Wrong image size: %v", b)
+	}
+
+	// Cell centers have their piece color; alike pieces share colors
+	if img.RGBAAt(7, 7) != st.pieceColor(1) || img.RGBAAt(27, 17) != s_emptyCell_ {
+		t.Errorf("Wrong cell colors: %v %v", img.RGBAAt(7, 7), img.RGBAAt(27, 17))
+	}
+	ptv := defs.NewPieceToValue()
+	ptv.Set(1, 5)
+	ptv.Set(2, 5)
+	st.Values = ptv
+	if st.pieceColor(1) != st.pieceColor(2) {
+		t.Error("Alike pieces should have the same color")
+	}
+}
+
+func TestFilmstripAndGIF(t *testing.T) {
+	m := grids.Matrix2d{
+		[]int{1, 0, 0},
+		[]int{2, 2, 0},
+	}
+	path := []defs.Command{
+		grids.NewGridMov2(1, 0, 1),
+		grids.NewGridMov2(1, 0, 1),
+		grids.NewGridMov2(2, 0, 1),
+	}
+	frames := MoveFrames(&m, path)
+	if len(frames) != 3 || frames[1].At(0, 2) != 1 {
+		t.Fatalf("Should be 3 frames, one per move: %d", len(frames))
+	}
+
+	st := ImageStyle{CellSize: 10}
+	img := FilmstripImage(frames, st, 2)
+	if b := img.Bounds(); b.Dx() != 70 || b.Dy() != 50 {
+		t.Errorf("Wrong filmstrip size: %v", b)
+	}
+
+	var out bytes.Buffer
+	if err := BoardsGIF(&out, frames, st, 0); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 3 {
+		t.Errorf("GIF should have 3 frames, has %d", len(anim.Image))
+	}
+}
+
+func TestEngelImages(t *testing.T) {
+	var g = &engel.EngelGame{}
+	g.Define([12]int{7, 6, 13, 14, 15, 16, 17, 18, 19, 20, 21, 8}, [12]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+	g.DefineIntersectionPositions([3]int{11, 0, 1}, [3]int{7, 6, 5})
+
+	start := g.State().(*engel.EngelState)
+	frames := EngelFrames(start, []*engel.EngelCommand{engel.NewEngelCommand(0, 1)})
+	if len(frames) != 2 {
+		t.Fatalf("Should be 2 frames, there are %d", len(frames))
+	}
+	if left, _ := frames[1].WheelPieces(); left[2] != 7 {
+		t.Errorf("Left wheel should be turned: %v", left)
+	}
+	if left, _ := start.WheelPieces(); left[0] != 7 {
+		t.Errorf("Start state should not change: %v", left)
+	}
+
+	// 21 pieces, each one drawn as a border disc and a colored disc, and the two wheels
+	svg := EngelSVG(g, start, ImageStyle{CellSize: 20, Labels: true})
+	if n := strings.Count(svg, "<circle"); n != 2*21+2 {
+		t.Errorf("SVG should have 44 circles, has %d", n)
+	}
+	if n := strings.Count(svg, "<text"); n != 21 {
+		t.Errorf("SVG should have 21 labels, has %d", n)
+	}
+
+	img := EngelFilmstripImage(g, frames, ImageStyle{CellSize: 20}, 0)
+	single := EngelImage(g, start, ImageStyle{CellSize: 20})
+	if img.Bounds().Dx() != 2*single.Bounds().Dx() {
+		t.Errorf("Filmstrip should be two images wide: %v %v", img.Bounds(), single.Bounds())
+	}
+}
@@ -0,0 +1,63 @@
+package render
+
+import "strconv"
+import "strings"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Each row half a cell to the right of the previous one
+func hexBoard(m *grids.Matrix2d, opts Options) string {
+	b := boardDrawer{m_: m, opts_: opts}
+	b.width_ = len(strconv.Itoa(m.Max())) + 2
+	if b.width_%2 == 1 {
+		b.width_++
+	}
+
+	var out strings.Builder
+	for r := 0; r < m.Rows(); r++ {
+		out.WriteString(strings.Repeat(" ", r*b.width_/2))
+		for c := 0; c < m.Cols(); c++ {
+			out.WriteString(b.label(m.At(r, c), ""))
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// Each cell followed by the way it points
+func triangleBoard(m *grids.Matrix2d, opts Options) string {
+	b := boardDrawer{m_: m, opts_: opts}
+	b.width_ = len(strconv.Itoa(m.Max())) + 2
+
+	up, down := "△", "▽"
+	if opts.ASCII {
+		up, down = "^", "v"
+	}
+
+	var out strings.Builder
+	for r := 0; r < m.Rows(); r++ {
+		for c := 0; c < m.Cols(); c++ {
+			if (r+c)%2 == 0 {
+				out.WriteString(b.label(m.At(r, c), up))
+			} else {
+				out.WriteString(b.label(m.At(r, c), down))
+			}
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// Piece id (or '.') and mark, right aligned in the cell width
+func (b *boardDrawer) label(id int, mark string) string {
+	s := "."
+	if id > 0 {
+		s = strconv.Itoa(id)
+	}
+	s += mark
+	pad := b.width_ - len([]rune(s))
+	if pad < 1 {
+		pad = 1
+	}
+	return strings.Repeat(" ", pad-1) + b.fill(" "+s, id)
+}
@@ -0,0 +1,85 @@
+package render
+
+import "bytes"
+import "strings"
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+func TestBoard(t *testing.T) {
+	m := grids.Matrix2d{
+		[]int{1, 1, 2},
+		[]int{1, 0, 0},
+	}
+
+	box := "" +
+		"┌───────┬───┐\n" +
+		"│ 1     │ 2 │\n" +
+		"│   ┌───┴───┤\n" +
+		"│   │       │\n" +
+		"└───┴───────┘\n"
+	if s := Board(&m, Options{}); s != box {
+		t.Errorf("Wrong box board:\n%s\nshould be:\n%s", s, box)
+	}
+
+	ascii := "" +
+		"+-------+---+\n" +
+		"| 1     | 2 |\n" +
+		"|   +---+---+\n" +
+		"|   |       |\n" +
+		"+---+-------+\n"
+	if s := Board(&m, Options{ASCII: true}); s != ascii {
+		t.Errorf("Wrong ASCII board:\n%s\nshould be:\n%s", s, ascii)
+	}
+}
+
+func TestLatticeBoards(t *testing.T) {
+	m := grids.Matrix2d{
+		[]int{1, 1, 0},
+		[]int{0, 12, 0},
+	}
+
+	hex := "" +
+		"   1   1   .\n" +
+		"     .  12   .\n"
+	if s := Board(&m, Options{ASCII: true, Lattice: grids.HEX_LATTICE}); s != hex {
+		t.Errorf("Wrong hex board:\n%s\nshould be:\n%s", s, hex)
+	}
+
+	triangle := "" +
+		"  1^  1v  .^\n" +
+		"  .v 12^  .v\n"
+	if s := Board(&m, Options{ASCII: true, Lattice: grids.TRIANGLE_LATTICE}); s != triangle {
+		t.Errorf("Wrong triangle board:\n%s\nshould be:\n%s", s, triangle)
+	}
+}
+
+func TestAnimate(t *testing.T) {
+	m := grids.Matrix2d{
+		[]int{1, 0, 0},
+		[]int{2, 2, 0},
+	}
+	path := []defs.Command{
+		grids.NewGridMov2(1, 0, 1),
+		grids.NewGridMov2(1, 0, 1),
+		grids.NewGridMov2(2, 0, 1),
+	}
+
+	frames := Frames(&m, path)
+	if len(frames) != 4 {
+		t.Fatalf("Should be 4 frames, there are %d", len(frames))
+	}
+	if frames[3].At(0, 2) != 1 || frames[3].At(1, 2) != 2 || frames[3].At(1, 0) != 0 {
+		t.Errorf("Wrong last frame: %v", *frames[3])
+	}
+	if m.At(0, 0) != 1 {
+		t.Error("Start board should not change")
+	}
+
+	var out bytes.Buffer
+	Animate(&out, &m, path, 0, false, Options{ASCII: true})
+	if !strings.Contains(out.String(), "Step 3/3, move 2: piece 2 right") {
+		t.Errorf("Wrong animation:\n%s", out.String())
+	}
+}
@@ -0,0 +1,166 @@
+package render
+
+import "fmt"
+import "image"
+import "image/color"
+import "math"
+import "strings"
+
+// A drawing made of simple shapes, in pixels. It can be written as SVG or rasterized.
+type scene struct {
+	width_  float64
+	height_ float64
+	shapes_ []shape
+}
+
+const (
+	RECT_SHAPE = iota
+	CIRCLE_SHAPE
+	RING_SHAPE
+	LINE_SHAPE
+	TEXT_SHAPE
+)
+
+type shape struct {
+	kind_ int
+
+	// Rect: top-left corner and size. Circle, ring: center and radius (w). Line: both ends.
+	x_, y_, w_, h_ float64
+	x2_, y2_       float64
+
+	// Stroke width of rings and lines
+	stroke_ float64
+
+	color_ color.RGBA
+	text_  string
+}
+
+func newScene(width float64, height float64) *scene {
+	return &scene{width_: width, height_: height}
+}
+
+func (s *scene) rect(x, y, w, h float64, c color.RGBA) {
+	s.shapes_ = append(s.shapes_, shape{kind_: RECT_SHAPE, x_: x, y_: y, w_: w, h_: h, color_: c})
+}
+
+func (s *scene) circle(x, y, r float64, c color.RGBA) {
+	s.shapes_ = append(s.shapes_, shape{kind_: CIRCLE_SHAPE, x_: x, y_: y, w_: r, color_: c})
+}
+
+func (s *scene) ring(x, y, r, stroke float64, c color.RGBA) {
+	s.shapes_ = append(s.shapes_, shape{kind_: RING_SHAPE, x_: x, y_: y, w_: r, stroke_: stroke, color_: c})
+}
+
+func (s *scene) line(x, y, x2, y2, stroke float64, c color.RGBA) {
+	s.shapes_ = append(s.shapes_, shape{kind_: LINE_SHAPE, x_: x, y_: y, x2_: x2, y2_: y2, stroke_: stroke, color_: c})
+}
+
+// Centered text. Only drawn in SVG: the standard library has no fonts.
+func (s *scene) text(x, y, size float64, t string, c color.RGBA) {
+	s.shapes_ = append(s.shapes_, shape{kind_: TEXT_SHAPE, x_: x, y_: y, h_: size, text_: t, color_: c})
+}
+
+// Adds all the shapes of another scene, moved by (dx, dy)
+func (s *scene) add(o *scene, dx float64, dy float64) {
+	for _, sh := range o.shapes_ {
+		sh.x_ += dx
+		sh.y_ += dy
+		sh.x2_ += dx
+		sh.y2_ += dy
+		s.shapes_ = append(s.shapes_, sh)
+	}
+}
+
+func svgColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// SVG document of the scene
+func (s *scene) svg() string {
+	var out strings.Builder
+
+	fmt.Fprintf(&out, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%g\" height=\"%g\" viewBox=\"0 0 %g %g\">\n", s.width_, s.height_, s.width_, s.height_)
+	for _, sh := range s.shapes_ {
+		switch sh.kind_ {
+		case RECT_SHAPE:
+			fmt.Fprintf(&out, "<rect x=\"%g\" y=\"%g\" width=\"%g\" height=\"%g\" fill=\"%s\"/>\n", sh.x_, sh.y_, sh.w_, sh.h_, svgColor(sh.color_))
+		case CIRCLE_SHAPE:
+			fmt.Fprintf(&out, "<circle cx=\"%g\" cy=\"%g\" r=\"%g\" fill=\"%s\"/>\n", sh.x_, sh.y_, sh.w_, svgColor(sh.color_))
+		case RING_SHAPE:
+			fmt.Fprintf(&out, "<circle cx=\"%g\" cy=\"%g\" r=\"%g\" fill=\"none\" stroke=\"%s\" stroke-width=\"%g\"/>\n", sh.x_, sh.y_, sh.w_, svgColor(sh.color_), sh.stroke_)
+		case LINE_SHAPE:
+			fmt.Fprintf(&out, "<line x1=\"%g\" y1=\"%g\" x2=\"%g\" y2=\"%g\" stroke=\"%s\" stroke-width=\"%g\" stroke-linecap=\"square\"/>\n", sh.x_, sh.y_, sh.x2_, sh.y2_, svgColor(sh.color_), sh.stroke_)
+		case TEXT_SHAPE:
+			fmt.Fprintf(&out, "<text x=\"%g\" y=\"%g\" font-family=\"sans-serif\" font-size=\"%g\" text-anchor=\"middle\" dominant-baseline=\"central\" fill=\"%s\">%s</text>\n", sh.x_, sh.y_, sh.h_, svgColor(sh.color_), sh.text_)
+		}
+	}
+	out.WriteString("</svg>\n")
+	return out.String()
+}
+
+// Rasterized scene. Text is not drawn.
+func (s *scene) image() *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, int(math.Ceil(s.width_)), int(math.Ceil(s.height_))))
+
+	for _, sh := range s.shapes_ {
+		switch sh.kind_ {
+		case RECT_SHAPE:
+			fillPixels(img, sh.x_, sh.y_, sh.x_+sh.w_, sh.y_+sh.h_, sh.color_, func(x, y float64) bool {
+				return true
+			})
+		case CIRCLE_SHAPE:
+			r := sh.w_
+			fillPixels(img, sh.x_-r, sh.y_-r, sh.x_+r, sh.y_+r, sh.color_, func(x, y float64) bool {
+				return math.Hypot(x-sh.x_, y-sh.y_) <= r
+			})
+		case RING_SHAPE:
+			r := sh.w_ + sh.stroke_/2
+			fillPixels(img, sh.x_-r, sh.y_-r, sh.x_+r, sh.y_+r, sh.color_, func(x, y float64) bool {
+				return math.Abs(math.Hypot(x-sh.x_, y-sh.y_)-sh.w_) <= sh.stroke_/2
+			})
+		case LINE_SHAPE:
+			half := sh.stroke_ / 2
+			fillPixels(img, math.Min(sh.x_, sh.x2_)-half, math.Min(sh.y_, sh.y2_)-half, math.Max(sh.x_, sh.x2_)+half, math.Max(sh.y_, sh.y2_)+half, sh.color_, func(x, y float64) bool {
+				return segmentDistance(x, y, sh) <= half
+			})
+		}
+	}
+	return img
+}
+
+// Sets the pixels of the box whose centers are inside the shape
+func fillPixels(img *image.RGBA, x0, y0, x1, y1 float64, c color.RGBA, inside func(x, y float64) bool) {
+	bounds := img.Bounds()
+	for py := int(math.Floor(y0)); py < int(math.Ceil(y1)); py++ {
+		for px := int(math.Floor(x0)); px < int(math.Ceil(x1)); px++ {
+			if !(image.Point{px, py}).In(bounds) {
+				continue
+			}
+			if inside(float64(px)+0.5, float64(py)+0.5) {
+				img.SetRGBA(px, py, c)
+			}
+		}
+	}
+}
+
+// Distance from a point to a line shape, measured with square ends (like the SVG stroke)
+func segmentDistance(x, y float64, sh shape) float64 {
+	dx, dy := sh.x2_-sh.x_, sh.y2_-sh.y_
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return math.Max(math.Abs(x-sh.x_), math.Abs(y-sh.y_))
+	}
+	ux, uy := dx/length, dy/length
+
+	// Coordinates along and across the segment
+	along := (x-sh.x_)*ux + (y-sh.y_)*uy
+	across := math.Abs(-(x-sh.x_)*uy + (y-sh.y_)*ux)
+
+	outside := 0.0
+	if along < 0 {
+		outside = -along
+	} else if along > length {
+		outside = along - length
+	}
+	return math.Max(outside, across)
+}
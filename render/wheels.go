@@ -0,0 +1,112 @@
+package render
+
+import "image"
+import "image/color"
+import "io"
+import "math"
+import "strconv"
+import "time"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games/engel"
+
+// Scene of an Engel puzzle state: both wheels, with a disc for each piece. Pieces at the intersection
+// are drawn once, at their left wheel position. Even and odd positions alternate big and small discs,
+// like the rectangles and triangles of the real puzzle.
+//
+// Each wheel is turned so that its middle intersection position faces the other wheel. Positions
+// follow the clockwise direction of the wheel turns.
+func wheelsScene(g *engel.EngelGame, s *engel.EngelState, st ImageStyle) *scene {
+	u := st.cellSize() / 2
+	radius := 6 * u
+	distance := radius * math.Sqrt(3)
+	margin := 2 * u
+
+	leftInter, rightInter := g.IntersectionPositions()
+	left, right := s.WheelPieces()
+
+	sc := newScene(distance+2*radius+2*margin, 2*radius+2*margin)
+	sc.rect(0, 0, sc.width_, sc.height_, s_background_)
+
+	cy := margin + radius
+	lx := margin + radius
+	rx := lx + distance
+
+	sc.ring(lx, cy, radius, u/6, s_border_)
+	sc.ring(rx, cy, radius, u/6, s_border_)
+
+	pieceColor := func(id int) color.RGBA {
+		if st.Values != nil {
+			return st.pieceColor(id)
+		}
+		return s_pieceColors_[g.PieceValue(id)%len(s_pieceColors_)]
+	}
+
+	drawWheel := func(cx float64, ids [12]int, middle int, offset float64, skip [3]int) {
+		for pos, id := range ids {
+			if pos == skip[0] || pos == skip[1] || pos == skip[2] {
+				continue
+			}
+			angle := offset + float64(pos-middle)*math.Pi/6
+			x, y := cx+radius*math.Cos(angle), cy+radius*math.Sin(angle)
+
+			r := 0.75 * u
+			if (pos-middle)%2 != 0 {
+				r = 0.5 * u
+			}
+			sc.circle(x, y, r+u/12, s_border_)
+			sc.circle(x, y, r, pieceColor(id))
+			if st.Labels {
+				sc.text(x, y, u/1.6, strconv.Itoa(id), s_border_)
+			}
+		}
+	}
+
+	drawWheel(lx, left, leftInter[1], 0, [3]int{-1, -1, -1})
+	drawWheel(rx, right, rightInter[1], math.Pi, rightInter)
+
+	return sc
+}
+
+// SVG drawing of an Engel puzzle state
+func EngelSVG(g *engel.EngelGame, s *engel.EngelState, st ImageStyle) string {
+	return wheelsScene(g, s, st).svg()
+}
+
+// Image of an Engel puzzle state, to be encoded with image/png. Labels are not drawn.
+func EngelImage(g *engel.EngelGame, s *engel.EngelState, st ImageStyle) *image.RGBA {
+	return wheelsScene(g, s, st).image()
+}
+
+// States after each wheel turn of the path, starting with the initial state.
+func EngelFrames(start *engel.EngelState, path []*engel.EngelCommand) []*engel.EngelState {
+	frames := []*engel.EngelState{start.Clone().(*engel.EngelState)}
+	for _, mov := range path {
+		s := frames[len(frames)-1].Clone().(*engel.EngelState)
+		s.Move(mov)
+		frames = append(frames, s)
+	}
+	return frames
+}
+
+func wheelsScenes(g *engel.EngelGame, frames []*engel.EngelState, st ImageStyle) []*scene {
+	var scenes []*scene
+	for _, s := range frames {
+		scenes = append(scenes, wheelsScene(g, s, st))
+	}
+	return scenes
+}
+
+// SVG filmstrip of a sequence of Engel states (see EngelFrames)
+func EngelFilmstripSVG(g *engel.EngelGame, frames []*engel.EngelState, st ImageStyle, perRow int) string {
+	return filmstripScene(wheelsScenes(g, frames, st), perRow).svg()
+}
+
+// Filmstrip image of a sequence of Engel states (see EngelFrames)
+func EngelFilmstripImage(g *engel.EngelGame, frames []*engel.EngelState, st ImageStyle, perRow int) *image.RGBA {
+	return filmstripScene(wheelsScenes(g, frames, st), perRow).image()
+}
+
+// Writes an animated GIF of a sequence of Engel states, 'delay' between them.
+func EngelGIF(w io.Writer, g *engel.EngelGame, frames []*engel.EngelState, st ImageStyle, delay time.Duration) error {
+	return writeGIF(w, wheelsScenes(g, frames, st), delay)
+}
@@ -0,0 +1,60 @@
+package search
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+
+// Game of the engine playing an explorable game (wheel puzzles). Searches start at its current state,
+// and leave it in any state.
+func Explorable(g defs.Explorable) Game[defs.GameState, defs.Command] {
+	return &explorableGame{g, g.State()}
+}
+
+// Game of the engine playing a sequential game (sliding blocks puzzles), with every valid movement.
+// Searches start at its current state, and leave it in any state.
+func Playable(g defs.Playable) Game[defs.SeqGameState, defs.Command] {
+	return &playableGame{g, g.State()}
+}
+
+type explorableGame struct {
+	game_  defs.Explorable
+	start_ defs.GameState
+}
+
+func (g *explorableGame) Start() defs.GameState {
+	return g.start_
+}
+
+func (g *explorableGame) Expand(s defs.GameState, yield func(mov defs.Command, next defs.GameState) bool) {
+	g.game_.SetState(s)
+	for _, mov := range g.game_.ValidMovements() {
+		g.game_.Move(mov)
+		next := g.game_.State()
+		next.SetPrevMov(mov)
+		g.game_.UndoMove(mov)
+
+		if !yield(mov, next) {
+			return
+		}
+	}
+}
+
+type playableGame struct {
+	game_  defs.Playable
+	start_ defs.SeqGameState
+}
+
+func (g *playableGame) Start() defs.SeqGameState {
+	return g.start_
+}
+
+func (g *playableGame) Expand(s defs.SeqGameState, yield func(mov defs.Command, next defs.SeqGameState) bool) {
+	g.game_.SetState(s)
+	for _, mov := range g.game_.ValidMovementsBFS(nil) {
+		g.game_.Move(mov)
+		next := g.game_.State()
+		g.game_.UndoMove(mov)
+
+		if !yield(mov, next) {
+			return
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package search
+
+import "time"
+import "github.com/fatih/color"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/utils"
+
+// States the engine can tell apart: equal states must have equal hashes
+type State[S any] interface {
+	ToHash() int
+	Equal(S) bool
+}
+
+// Games the engine searches: any game implementing it gets breadth first solving, extremal search
+// and full exploration.
+type Game[S State[S], M any] interface {
+	// State where the searches start
+	Start() S
+
+	// Calls yield with each movement from s and the state it reaches, until yield returns false.
+	// It must not modify s.
+	Expand(s S, yield func(mov M, next S) bool)
+}
+
+// What a search looks for
+type Mode int
+
+const (
+	SOLVE     Mode = iota // Shortest path to a state reaching the goal
+	EXTREMALS             // States farthest from the start
+	EXPLORE               // Statistics of all reachable states
+)
+
+// State reached by a search, with the movement reaching it from its parent
+type Node[S any, M any] struct {
+	state_  S
+	parent_ *Node[S, M]
+	mov_    M
+	depth_  int
+}
+
+func (n *Node[S, M]) State() S {
+	return n.state_
+}
+
+// Node of the previous state, nil for the start
+func (n *Node[S, M]) Parent() *Node[S, M] {
+	return n.parent_
+}
+
+// Movement from the parent (zero value for the start)
+func (n *Node[S, M]) Mov() M {
+	return n.mov_
+}
+
+// Number of movements from the start
+func (n *Node[S, M]) Depth() int {
+	return n.depth_
+}
+
+// Movements from the start
+func (n *Node[S, M]) Path() []M {
+	path := make([]M, n.depth_)
+	for x := n; x.parent_ != nil; x = x.parent_ {
+		path[x.depth_-1] = x.mov_
+	}
+	return path
+}
+
+// Breadth first search engine: each state is reached by a shortest path, in number of movements
+type Engine[S State[S], M any] struct {
+
+	// Params
+	maxDepth_  int
+	maxStates_ int
+	hash_      func(S) int
+	equal_     func(S, S) bool
+	goal_      func(S) bool
+	onGoal_    func(*Node[S, M]) bool
+	onState_   func(*Node[S, M])
+	onRevisit_ func(*Node[S, M], *Node[S, M]) bool
+	onExpand_  func(*Node[S, M]) string
+
+	// Stats
+	countStates_  utils.ScalarStatistic
+	revisits_     utils.ScalarStatistic
+	nodesDegree_  utils.ScalarStatistic
+	frontierSize_ utils.RangeStatistic
+	depthDistr_   utils.RangeHistogram
+
+	// Algorithm state
+	visitedStates_ map[int][]*Node[S, M]
+	frontier_      utils.Queue[*Node[S, M]]
+	counted_       int
+	expanded_      int
+	found_         *Node[S, M]
+	extremals_     []*Node[S, M]
+	distance_      int
+	endStatus_     string
+	duration_      time.Duration
+}
+
+// States at depth maxDepth are not expanded. The search counts the start and each state taken from the
+// frontier, and stops when the count reaches maxStates. 0 is no limit.
+func (e *Engine[S, M]) SetLimits(maxDepth int, maxStates int) {
+	e.maxDepth_ = maxDepth
+	e.maxStates_ = maxStates
+}
+
+// States the search tells apart: equal states must have equal hashes. By default, their ToHash and
+// Equal methods.
+func (e *Engine[S, M]) SetEquivalence(hash func(S) int, equal func(old S, s S) bool) {
+	e.hash_ = hash
+	e.equal_ = equal
+}
+
+// Condition of the states SOLVE searches for
+func (e *Engine[S, M]) SetGoal(goal func(S) bool) {
+	e.goal_ = goal
+}
+
+// Calls fn for each new state reaching the goal (SOLVE). The search stops if fn returns true; without
+// fn, it stops at the first one.
+func (e *Engine[S, M]) OnGoal(fn func(n *Node[S, M]) bool) {
+	e.onGoal_ = fn
+}
+
+// Calls fn for each new state, once added to the frontier
+func (e *Engine[S, M]) OnState(fn func(n *Node[S, M])) {
+	e.onState_ = fn
+}
+
+// Calls fn when a movement reaches the state of an old node. The child node is not kept, unless fn
+// pushes it (see Push). If fn returns true, the other movements from the child parent are skipped: the
+// game knows their states have been reached already.
+func (e *Engine[S, M]) OnRevisit(fn func(old *Node[S, M], child *Node[S, M]) bool) {
+	e.onRevisit_ = fn
+}
+
+// Calls fn before expanding each state. If fn returns a reason, the search stops with it as end status.
+func (e *Engine[S, M]) OnExpand(fn func(n *Node[S, M]) string) {
+	e.onExpand_ = fn
+}
+
+// Adds a node to the frontier again, to expand it once more: for instance, a revisit reaching the state
+// of an old node in a better way for the game.
+func (e *Engine[S, M]) Push(n *Node[S, M]) {
+	e.frontier_.PushBack(n)
+	e.frontierSize_.Add(e.frontier_.Size())
+}
+
+// Number of different states reached by the last search
+func (e *Engine[S, M]) StatesCount() int {
+	return e.countStates_.Total()
+}
+
+// Number of states counted for the maxStates limit: the start, and the states taken from the frontier
+func (e *Engine[S, M]) Counted() int {
+	return e.counted_
+}
+
+// Number of states expanded by the last search
+func (e *Engine[S, M]) Expanded() int {
+	return e.expanded_
+}
+
+// Number of movements reaching an already known state
+func (e *Engine[S, M]) Revisits() int {
+	return e.revisits_.Total()
+}
+
+// Number of states in the frontier, waiting to be expanded
+func (e *Engine[S, M]) FrontierSize() int {
+	return e.frontier_.Size()
+}
+
+// Number of states reached at each depth by the last search
+func (e *Engine[S, M]) DepthDistribution() map[int]int {
+	return e.depthDistr_.Data()
+}
+
+// First node reaching the goal (SOLVE), nil if not found
+func (e *Engine[S, M]) Found() *Node[S, M] {
+	return e.found_
+}
+
+// Nodes of the farthest states reached (EXTREMALS)
+func (e *Engine[S, M]) Extremals() []*Node[S, M] {
+	return e.extremals_
+}
+
+// Depth of the farthest states reached
+func (e *Engine[S, M]) Distance() int {
+	return e.distance_
+}
+
+// Reason why the last search stopped
+func (e *Engine[S, M]) EndStatus() string {
+	return e.endStatus_
+}
+
+// Duration of the last search
+func (e *Engine[S, M]) Duration() time.Duration {
+	return e.duration_
+}
+
+// Prints the statistics of the last search
+func (e *Engine[S, M]) Resume(out *color.Color) {
+	e.countStates_.Resume(out)
+	e.revisits_.Resume(out)
+	e.nodesDegree_.ResumeAv(out)
+	e.frontierSize_.ResumeRange(out)
+	out.Printf("\n Max depth: %d", e.distance_)
+	e.depthDistr_.ResumeHistogram(out)
+}
+
+func (e *Engine[S, M]) init() {
+	e.countStates_ = utils.ScalarStatistic{}
+	e.revisits_ = utils.ScalarStatistic{}
+	e.nodesDegree_ = utils.ScalarStatistic{}
+	e.frontierSize_ = utils.RangeStatistic{}
+	e.countStates_.Set("States")
+	e.revisits_.Set("Revisited states")
+	e.nodesDegree_.Set("Node degree")
+	e.frontierSize_.Set("Frontier size")
+	e.depthDistr_.Set("Depth states distribution")
+
+	e.visitedStates_ = make(map[int][]*Node[S, M])
+	e.frontier_ = utils.Queue[*Node[S, M]]{}
+	e.counted_ = 1
+	e.expanded_ = 0
+	e.found_ = nil
+	e.extremals_ = nil
+	e.distance_ = 0
+	e.endStatus_ = ""
+}
+
+// Searches the states reachable from the start of the game, in the mode
+func (e *Engine[S, M]) Run(g Game[S, M], mode Mode) {
+	if mode == SOLVE && e.goal_ == nil {
+		panic("[Engine::Run] no goal to solve!")
+	}
+	e.init()
+
+	tStart := time.Now()
+	defer func() {
+		e.duration_ = time.Since(tStart)
+	}()
+
+	if e.add(&Node[S, M]{state_: g.Start()}, mode) {
+		return
+	}
+
+	for e.frontier_.Size() > 0 {
+		n := e.frontier_.PopFront()
+
+		e.counted_++
+		if e.maxStates_ > 0 && e.counted_ >= e.maxStates_ {
+			e.endStatus_ = "Max states reached."
+			return
+		}
+		if e.maxDepth_ > 0 && n.depth_ >= e.maxDepth_ {
+			e.endStatus_ = "Max depth reached."
+			return
+		}
+		if e.onExpand_ != nil {
+			if reason := e.onExpand_(n); reason != "" {
+				e.endStatus_ = reason
+				return
+			}
+		}
+		e.expanded_++
+
+		degree := 0
+		found := false
+		g.Expand(n.state_, func(mov M, next S) bool {
+			degree++
+			child := &Node[S, M]{next, n, mov, n.depth_ + 1}
+			if old := e.visited(child); old != nil {
+				e.revisits_.Incr()
+				return e.onRevisit_ == nil || !e.onRevisit_(old, child)
+			}
+			found = e.add(child, mode)
+			return !found
+		})
+		e.nodesDegree_.Add(degree)
+		if found {
+			return
+		}
+	}
+	e.endStatus_ = "All states explored, no more states in queue"
+}
+
+// Node of an already known state equal to the node one, nil if it is new
+func (e *Engine[S, M]) visited(n *Node[S, M]) *Node[S, M] {
+	for _, old := range e.visitedStates_[e.hash(n.state_)] {
+		if e.equal(old.state_, n.state_) {
+			return old
+		}
+	}
+	return nil
+}
+
+func (e *Engine[S, M]) hash(s S) int {
+	if e.hash_ == nil {
+		return s.ToHash()
+	}
+	return e.hash_(s)
+}
+
+func (e *Engine[S, M]) equal(old S, s S) bool {
+	if e.equal_ == nil {
+		return old.Equal(s)
+	}
+	return e.equal_(old, s)
+}
+
+// Adds a new state. Returns true if it reaches the goal, ending the search.
+func (e *Engine[S, M]) add(n *Node[S, M], mode Mode) bool {
+	h := e.hash(n.state_)
+	e.visitedStates_[h] = append(e.visitedStates_[h], n)
+	e.countStates_.Incr()
+	e.depthDistr_.Add(n.depth_, 1)
+
+	if n.depth_ > e.distance_ {
+		e.distance_ = n.depth_
+		e.extremals_ = e.extremals_[:0]
+	}
+	if mode == EXTREMALS {
+		e.extremals_ = append(e.extremals_, n)
+	}
+
+	e.frontier_.PushBack(n)
+	e.frontierSize_.Add(e.frontier_.Size())
+	if e.onState_ != nil {
+		e.onState_(n)
+	}
+
+	if mode == SOLVE && e.goal_(n.state_) {
+		if e.found_ == nil {
+			e.found_ = n
+		}
+		if e.onGoal_ == nil || e.onGoal_(n) {
+			e.endStatus_ = "Goal found"
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package search
+
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+// Positions of a ring of 10 cells, moving forward 1 or 2 cells
+type cell int
+
+func (c cell) ToHash() int {
+	return int(c)
+}
+func (c cell) Equal(o cell) bool {
+	return c == o
+}
+
+type ring struct{}
+
+func (ring) Start() cell {
+	return 0
+}
+func (ring) Expand(c cell, yield func(mov int, next cell) bool) {
+	for _, mov := range []int{1, 2} {
+		if !yield(mov, (c+cell(mov))%10) {
+			return
+		}
+	}
+}
+
+func TestModes(t *testing.T) {
+	var e Engine[cell, int]
+
+	e.Run(ring{}, EXTREMALS)
+	if e.StatesCount() != 10 || e.Distance() != 5 || len(e.Extremals()) != 1 || e.Extremals()[0].State() != 9 {
+		t.Errorf("Extremals: %d states, distance %d, extremals %v", e.StatesCount(), e.Distance(), e.Extremals())
+	}
+	expected := []int{1, 2, 2, 2, 2, 1}
+	for d, n := range e.DepthDistribution() {
+		if expected[d] != n {
+			t.Errorf("Depth %d: %d states, expected %d", d, n, expected[d])
+		}
+	}
+
+	e.SetGoal(func(c cell) bool { return c == 7 })
+	e.Run(ring{}, SOLVE)
+	if e.Found() == nil {
+		t.Fatalf("Goal not found: %s", e.EndStatus())
+	}
+	sum := 0
+	for _, mov := range e.Found().Path() {
+		sum += mov
+	}
+	if e.Found().Depth() != 4 || len(e.Found().Path()) != 4 || sum != 7 {
+		t.Errorf("Path to 7: %v", e.Found().Path())
+	}
+
+	// Every odd cell, the first one found
+	goals := 0
+	e.SetGoal(func(c cell) bool { return c%2 == 1 })
+	e.OnGoal(func(n *Node[cell, int]) bool {
+		goals++
+		return false
+	})
+	e.Run(ring{}, SOLVE)
+	if goals != 5 || e.Found().State() != 1 {
+		t.Errorf("Goals: %d found, first %v", goals, e.Found().State())
+	}
+
+	e.SetLimits(2, 0)
+	e.Run(ring{}, EXPLORE)
+	if e.StatesCount() != 5 || e.EndStatus() != "Max depth reached." || len(e.Extremals()) != 0 {
+		t.Errorf("Depth limit: %d states, %s", e.StatesCount(), e.EndStatus())
+	}
+
+	// The start and the popped states are counted
+	e.SetLimits(0, 4)
+	e.Run(ring{}, EXPLORE)
+	if e.Expanded() != 2 || e.EndStatus() != "Max states reached." {
+		t.Errorf("States limit: %d expanded, %s", e.Expanded(), e.EndStatus())
+	}
+
+	// Cells 5 apart told apart no more
+	e.SetLimits(0, 0)
+	e.SetEquivalence(func(c cell) int { return int(c) % 5 }, func(old cell, c cell) bool { return old%5 == c%5 })
+	e.Run(ring{}, EXPLORE)
+	if e.StatesCount() != 5 {
+		t.Errorf("Equivalence: %d states", e.StatesCount())
+	}
+}
+
+// Skipping siblings on revisits, and cancelling.
+func TestHooks(t *testing.T) {
+	var e Engine[cell, int]
+	e.OnRevisit(func(old *Node[cell, int], child *Node[cell, int]) bool {
+		return true
+	})
+	e.Run(ring{}, EXPLORE)
+	if e.StatesCount() != 10 || e.Revisits() == 0 {
+		t.Errorf("Revisits: %d states, %d revisits", e.StatesCount(), e.Revisits())
+	}
+
+	// A revisiting node pushed again is expanded too
+	pushed := false
+	e.OnRevisit(func(old *Node[cell, int], child *Node[cell, int]) bool {
+		if !pushed {
+			pushed = true
+			e.Push(child)
+		}
+		return false
+	})
+	e.Run(ring{}, EXPLORE)
+	if e.StatesCount() != 10 || e.Expanded() != 11 {
+		t.Errorf("Push: %d states, %d expanded", e.StatesCount(), e.Expanded())
+	}
+
+	e.OnExpand(func(n *Node[cell, int]) string {
+		if n.Depth() < 2 {
+			return ""
+		}
+		return "Search cancelled."
+	})
+	e.Run(ring{}, EXPLORE)
+	if e.EndStatus() != "Search cancelled." || e.Expanded() != 3 {
+		t.Errorf("Cancel: %s, %d expanded", e.EndStatus(), e.Expanded())
+	}
+}
+
+// Sliding blocks puzzles through their adapter: the Pennant states, and a shortest path in steps.
+func TestPlayable(t *testing.T) {
+	var g games.SBGame
+	g.Define(&grids.Matrix2d{
+		[]int{2, 2, 1, 1},
+		[]int{2, 2, 3, 3},
+		[]int{5, 4, 0, 0},
+		[]int{6, 7, 8, 8},
+		[]int{6, 7, 9, 9},
+	})
+	g.AutoAlikePieces()
+	g.Build()
+
+	start := g.State()
+
+	var e Engine[defs.SeqGameState, defs.Command]
+	e.Run(Playable(&g), EXTREMALS)
+	if e.StatesCount() != 1398 || e.EndStatus() != "All states explored, no more states in queue" {
+		t.Errorf("Pennant: %d states, %s", e.StatesCount(), e.EndStatus())
+	}
+
+	goal := games.Template(&grids.Matrix2d{
+		[]int{0, 0, 0, 0},
+		[]int{0, 0, 0, 0},
+		[]int{0, 0, 0, 0},
+		[]int{2, 2, 0, 0},
+		[]int{2, 2, 0, 0},
+	})
+	// Searches start at the current state, and leave the game in any state
+	g.SetState(start)
+	e.SetGoal(goal.Reached)
+	e.Run(Playable(&g), SOLVE)
+	if e.Found() == nil {
+		t.Fatalf("Pennant not solved: %s", e.EndStatus())
+	}
+
+	g.SetState(start)
+	for _, m := range e.Found().Path() {
+		g.Move(m)
+	}
+	if !goal.Reached(g.State()) {
+		t.Errorf("Path of %d steps does not reach the goal", e.Found().Depth())
+	}
+}
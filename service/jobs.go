@@ -0,0 +1,248 @@
+package service
+
+import "fmt"
+import "time"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/finder"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Job kinds
+const (
+	// Shortest solution of a sliding blocks puzzle (SbpBfsFinder.SolvePuzzle)
+	SOLVE_JOB = "solve"
+
+	// Farthest states from the start (SbpBfsFinder.FindExtremals), or the whole reachable space of an
+	// Engel puzzle (Analyzer.Explore)
+	ANALYZE_JOB = "analyze"
+)
+
+// Job status
+const (
+	QUEUED    = "queued"
+	RUNNING   = "running"
+	DONE      = "done"
+	CANCELLED = "cancelled"
+	FAILED    = "failed"
+)
+
+// Max number of farthest states returned by an analysis
+const MAX_FARTHEST = 3
+
+// A search on a puzzle, run by one of the service workers. Fields are guarded by the service mutex,
+// except the control, which the running finder updates.
+type Job struct {
+	id_        string
+	kind_      string
+	puzzle_    *puzzles.Puzzle
+	maxDepth_  int
+	maxStates_ int
+
+	control_ finder.SearchControl
+
+	status_   string
+	err_      string
+	result_   *resultJSON
+	started_  time.Time
+	finished_ time.Time
+}
+
+// Runs the search, and returns its result. Games panic on inconsistent puzzles: the panic is returned
+// as an error.
+func (j *Job) run() (res *resultJSON, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	switch {
+	case j.kind_ == SOLVE_JOB:
+		return j.solve(), nil
+	case j.puzzle_.Kind == puzzles.SBP_PUZZLE:
+		return j.findFarthest(), nil
+	default:
+		return j.explore(), nil
+	}
+}
+
+func (j *Job) newFinder() *finder.SbpBfsFinder {
+	f := &finder.SbpBfsFinder{}
+	f.SilentMode(true)
+	f.SetLimits(j.maxDepth_, j.maxStates_)
+	f.SetControl(&j.control_)
+	return f
+}
+
+// Solves the puzzle in its metric: move metric puzzles with SbpBfsFinder, the others with
+// MoveExtremalFinder
+func (j *Job) solve() *resultJSON {
+	metric := j.puzzle_.Metric
+	if metric == nil {
+		metric = defs.MoveMetric()
+	}
+	if metric != defs.MoveMetric() {
+		return j.solveInMetric(metric)
+	}
+
+	f := j.newFinder()
+	f.SetHardOptimal(true)
+	f.DetectGoal(j.puzzle_.Target())
+	f.SolvePuzzle(j.puzzle_.NewSBGame())
+
+	found, moves, duration := f.GetResult()
+	return solveResult(f.EndStatus(), f.StatesCount(), duration, found, moves, metric, f.Solution())
+}
+
+func (j *Job) solveInMetric(metric defs.Metric) *resultJSON {
+	var f finder.MoveExtremalFinder
+	f.SilentMode(true)
+	f.SetLimits(j.maxDepth_, j.maxStates_)
+	f.SetControl(&j.control_)
+	f.SetMetric(metric)
+	f.DetectGoal(j.puzzle_.Target())
+	f.SolvePuzzle(j.puzzle_.NewSBGame())
+
+	found, length, duration := f.GetResult()
+	return solveResult(f.EndStatus(), f.StatesCount(), duration, found, length, metric, f.Solution())
+}
+
+// Result of a solve job, with the solution steps as piece id and displacement
+func solveResult(status string, states int, duration time.Duration, found bool, length int, metric defs.Metric, solution []defs.Command) *resultJSON {
+	res := &resultJSON{
+		Status:   status,
+		States:   states,
+		Duration: duration.String(),
+		Solve:    &solveJSON{Found: found, Moves: length, Metric: metric.Name()},
+	}
+	for _, c := range solution {
+		dRow, dCol := c.(*grids.GridMov2).Translation()
+		res.Solve.Steps = append(res.Solve.Steps, [3]int{c.PieceId(), dRow, dCol})
+	}
+	return res
+}
+
+func (j *Job) findFarthest() *resultJSON {
+	f := j.newFinder()
+	tStart := time.Now()
+	f.FindExtremals(j.puzzle_.NewSBGame())
+
+	dist, states := f.Extremals()
+	res := &resultJSON{
+		Status:   f.EndStatus(),
+		States:   f.StatesCount(),
+		Duration: time.Since(tStart).String(),
+		Analysis: &analysisJSON{Distance: dist, Count: len(states)},
+	}
+	for i := 0; i < len(states) && i < MAX_FARTHEST; i++ {
+		res.Analysis.Farthest = append(res.Analysis.Farthest, *states[i].(*games.SBPState).Grid())
+	}
+	return res
+}
+
+func (j *Job) explore() *resultJSON {
+	var a finder.Analyzer
+	a.SilentMode(true)
+	a.SetLimits(j.maxDepth_, j.maxStates_)
+	a.SetControl(&j.control_)
+	a.Explore(j.puzzle_.NewEngelGame())
+
+	res := &resultJSON{
+		Status:   a.EndStatus(),
+		States:   a.StatesCount(),
+		Duration: a.Duration().String(),
+		Analysis: &analysisJSON{Depths: make(map[int]int)},
+	}
+
+	// Depths are counted from the initial state depth, the shallowest one
+	depths := a.DepthDistribution()
+	first := -1
+	for d := range depths {
+		if first < 0 || d < first {
+			first = d
+		}
+	}
+	for d, n := range depths {
+		res.Analysis.Depths[d-first] = n
+		if d-first >= res.Analysis.Distance {
+			res.Analysis.Distance = d - first
+			res.Analysis.Count = n
+		}
+	}
+	return res
+}
+
+// Job as reported by the service
+type jobJSON struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	Puzzle string `json:"puzzle"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	// Progress: states explored so far and depth of the last one
+	Explored  int    `json:"explored"`
+	Depth     int    `json:"depth"`
+	MaxStates int    `json:"maxStates"`
+	MaxDepth  int    `json:"maxDepth"`
+	Elapsed   string `json:"elapsed"`
+}
+
+type resultJSON struct {
+	// Why the search stopped, as given by the finder
+	Status   string `json:"status"`
+	States   int    `json:"states"`
+	Duration string `json:"duration"`
+
+	Solve    *solveJSON    `json:"solve,omitempty"`
+	Analysis *analysisJSON `json:"analysis,omitempty"`
+}
+
+type solveJSON struct {
+	Found bool `json:"found"`
+
+	// Length of the solution, in the puzzle metric
+	Moves  int    `json:"moves"`
+	Metric string `json:"metric"`
+
+	// Piece id and displacement of each step
+	Steps [][3]int `json:"steps"`
+}
+
+type analysisJSON struct {
+	// Distance of the farthest states found, and how many there are
+	Distance int `json:"distance"`
+	Count    int `json:"count"`
+
+	// Sliding blocks puzzles: some of the farthest boards
+	Farthest []grids.Matrix2d `json:"farthest,omitempty"`
+
+	// Engel puzzles: number of states at each distance
+	Depths map[int]int `json:"depths,omitempty"`
+}
+
+// Report of the job. Must be called with the service mutex held.
+func (j *Job) report() jobJSON {
+	res := jobJSON{
+		ID:        j.id_,
+		Kind:      j.kind_,
+		Puzzle:    j.puzzle_.Name,
+		Status:    j.status_,
+		Error:     j.err_,
+		MaxStates: j.maxStates_,
+		MaxDepth:  j.maxDepth_,
+	}
+	res.Explored, res.Depth = j.control_.Progress()
+
+	switch {
+	case j.started_.IsZero():
+		res.Elapsed = "0s"
+	case j.finished_.IsZero():
+		res.Elapsed = time.Since(j.started_).String()
+	default:
+		res.Elapsed = j.finished_.Sub(j.started_).String()
+	}
+	return res
+}
@@ -0,0 +1,438 @@
+package service
+
+import "encoding/json"
+import "fmt"
+import "net/http"
+import "strconv"
+import "strings"
+import "sync"
+import "time"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/formats"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Default limits of a job
+const (
+	MAX_DEPTH  = 300
+	MAX_STATES = 2000000
+)
+
+// Default number of finished jobs kept for their clients
+const MAX_FINISHED = 1000
+
+// HTTP/JSON solver service. Puzzles are submitted, then solve or analysis jobs on them are queued and
+// run by a fixed number of workers; clients poll the jobs progress, cancel them and fetch their results.
+//
+//	POST /puzzles                 submits a puzzle definition, returns its id
+//	POST /jobs                    queues a job on a submitted or catalog puzzle
+//	GET  /jobs                    lists the jobs
+//	GET  /jobs/{id}               job status and progress
+//	POST /jobs/{id}/cancel        cancels a queued or running job
+//	GET  /jobs/{id}/result        result of a finished job
+type Service struct {
+	mutex_ sync.Mutex
+
+	puzzles_    map[string]*puzzles.Puzzle
+	jobs_       map[string]*Job
+	jobsOrder_  []*Job
+	lastPuzzle_ int
+	lastJob_    int
+
+	// Jobs waiting for a worker. Submitting fails when there are queueSize_ of them; cancelled jobs
+	// leave the queue at once.
+	queue_     []*Job
+	queueSize_ int
+	wakeup_    *sync.Cond
+	workers_   sync.WaitGroup
+	closed_    bool
+
+	// Finished jobs are forgotten, oldest first, when there are more than maxFinished_
+	maxFinished_ int
+
+	maxDepth_  int
+	maxStates_ int
+
+	mux_ *http.ServeMux
+}
+
+// Starts a service with 'workers' concurrent searches and up to 'queueSize' waiting jobs
+func NewService(workers int, queueSize int) *Service {
+	if workers < 1 || queueSize < 0 {
+		panic("[service::NewService] needs one worker at least, and a non negative queue size")
+	}
+
+	s := &Service{
+		puzzles_:     make(map[string]*puzzles.Puzzle),
+		jobs_:        make(map[string]*Job),
+		queueSize_:   queueSize,
+		maxFinished_: MAX_FINISHED,
+		maxDepth_:    MAX_DEPTH,
+		maxStates_:   MAX_STATES,
+		mux_:         http.NewServeMux(),
+	}
+	s.wakeup_ = sync.NewCond(&s.mutex_)
+
+	s.mux_.HandleFunc("/puzzles", s.handlePuzzles)
+	s.mux_.HandleFunc("/jobs", s.handleJobs)
+	s.mux_.HandleFunc("/jobs/", s.handleJob)
+
+	s.workers_.Add(workers)
+	for i := 0; i < workers; i++ {
+		go s.work()
+	}
+	return s
+}
+
+// Upper limits of the jobs: requests asking for more are capped
+func (s *Service) SetLimits(maxDepth int, maxStates int) {
+	s.mutex_.Lock()
+	defer s.mutex_.Unlock()
+
+	s.maxDepth_ = maxDepth
+	s.maxStates_ = maxStates
+}
+
+// Number of finished (done, failed or cancelled) jobs kept for their clients. Older ones are forgotten.
+func (s *Service) SetMaxFinished(maxFinished int) {
+	s.mutex_.Lock()
+	defer s.mutex_.Unlock()
+
+	s.maxFinished_ = maxFinished
+	s.evict()
+}
+
+// Cancels all the jobs and waits for the workers to stop
+func (s *Service) Close() {
+	s.mutex_.Lock()
+	if !s.closed_ {
+		s.closed_ = true
+		for _, j := range s.jobsOrder_ {
+			s.cancel(j)
+		}
+		s.wakeup_.Broadcast()
+	}
+	s.mutex_.Unlock()
+
+	s.workers_.Wait()
+}
+
+// Implements http.Handler
+func (s *Service) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux_.ServeHTTP(w, r)
+}
+
+// Serves the API at addr (e.g. "localhost:8081") until it fails.
+func (s *Service) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s)
+}
+
+// Runs queued jobs until the service is closed
+func (s *Service) work() {
+	defer s.workers_.Done()
+
+	for {
+		s.mutex_.Lock()
+		for len(s.queue_) == 0 && !s.closed_ {
+			s.wakeup_.Wait()
+		}
+		if s.closed_ {
+			s.mutex_.Unlock()
+			return
+		}
+		j := s.queue_[0]
+		s.queue_[0] = nil
+		s.queue_ = s.queue_[1:]
+
+		j.status_ = RUNNING
+		j.started_ = time.Now()
+		s.mutex_.Unlock()
+
+		res, err := j.run()
+
+		s.mutex_.Lock()
+		j.finished_ = time.Now()
+		switch {
+		case err != nil:
+			j.status_ = FAILED
+			j.err_ = err.Error()
+		case j.control_.Cancelled():
+			j.status_ = CANCELLED
+		default:
+			j.status_ = DONE
+			j.result_ = res
+		}
+		s.evict()
+		s.mutex_.Unlock()
+	}
+}
+
+// Must be called with the mutex held
+func (s *Service) cancel(j *Job) {
+	switch j.status_ {
+	case QUEUED:
+		j.status_ = CANCELLED
+		j.finished_ = time.Now()
+		for i, q := range s.queue_ {
+			if q == j {
+				s.queue_ = append(s.queue_[:i], s.queue_[i+1:]...)
+				break
+			}
+		}
+	case RUNNING:
+		// The worker sets the status when the finder stops
+		j.control_.Cancel()
+	}
+}
+
+// Forgets the oldest finished jobs, down to maxFinished_ of them. Must be called with the mutex held.
+func (s *Service) evict() {
+	finished := 0
+	for _, j := range s.jobsOrder_ {
+		if j.status_ != QUEUED && j.status_ != RUNNING {
+			finished++
+		}
+	}
+
+	kept := s.jobsOrder_[:0]
+	for _, j := range s.jobsOrder_ {
+		if finished > s.maxFinished_ && j.status_ != QUEUED && j.status_ != RUNNING {
+			delete(s.jobs_, j.id_)
+			finished--
+			continue
+		}
+		kept = append(kept, j)
+	}
+	for i := len(kept); i < len(s.jobsOrder_); i++ {
+		s.jobsOrder_[i] = nil
+	}
+	s.jobsOrder_ = kept
+}
+
+// Puzzle definition: boards as matrices of piece ids, or as text in a collection format
+// (see formats.ReadCollection). SBPSearch levels hold both boards in StartText.
+type puzzleRequest struct {
+	Name    string `json:"name"`
+	Author  string `json:"author"`
+	Optimal int    `json:"optimal"`
+
+	// Metric of the solutions and of Optimal, by name (see defs.MetricByName). Move metric if empty.
+	Metric string `json:"metric"`
+
+	Start grids.Matrix2d `json:"start"`
+	Goal  grids.Matrix2d `json:"goal"`
+	Fixed []int          `json:"fixed"`
+
+	Format    string `json:"format"`
+	StartText string `json:"startText"`
+	GoalText  string `json:"goalText"`
+}
+
+type puzzleResponse struct {
+	ID string `json:"id"`
+}
+
+type jobRequest struct {
+	// Id of a submitted puzzle, or name of a catalog puzzle
+	Puzzle string `json:"puzzle"`
+	Kind   string `json:"kind"`
+
+	// Zero is the puzzle default
+	MaxDepth  int `json:"maxDepth"`
+	MaxStates int `json:"maxStates"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Submits a puzzle definition
+func (s *Service) handlePuzzles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{"use POST"})
+		return
+	}
+
+	var req puzzleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{"invalid request: " + err.Error()})
+		return
+	}
+	p, err := req.build()
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+
+	s.mutex_.Lock()
+	s.lastPuzzle_++
+	id := "p" + strconv.Itoa(s.lastPuzzle_)
+	if p.Name == "" {
+		p.Name = id
+	}
+	s.puzzles_[id] = p
+	s.mutex_.Unlock()
+
+	writeJSON(w, http.StatusCreated, puzzleResponse{id})
+}
+
+func (req *puzzleRequest) build() (p *puzzles.Puzzle, err error) {
+	switch req.Format {
+	case "":
+		p, err = formats.NewSBPPuzzle(req.Start, req.Goal, req.Fixed)
+	case formats.ASCII_FORMAT:
+		p, err = formats.ParseASCII(req.StartText, req.GoalText)
+	case formats.NUMERIC_FORMAT:
+		p, err = formats.ParseNumeric(req.StartText, req.GoalText)
+	case formats.SBPSEARCH_FORMAT:
+		p, err = formats.ParseSBPSearch(req.StartText)
+	default:
+		err = fmt.Errorf("unknown format '%s'", req.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Metric != "" {
+		if p.Metric = defs.MetricByName(req.Metric); p.Metric == nil {
+			return nil, fmt.Errorf("unknown metric '%s'", req.Metric)
+		}
+	}
+
+	p.Name = req.Name
+	p.Author = req.Author
+	p.Optimal = req.Optimal
+	return p, nil
+}
+
+// Queues a job (POST), or lists all of them (GET)
+func (s *Service) handleJobs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.mutex_.Lock()
+		res := []jobJSON{}
+		for _, j := range s.jobsOrder_ {
+			res = append(res, j.report())
+		}
+		s.mutex_.Unlock()
+		writeJSON(w, http.StatusOK, res)
+
+	case http.MethodPost:
+		var req jobRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{"invalid request: " + err.Error()})
+			return
+		}
+		status, res := s.submit(req)
+		writeJSON(w, status, res)
+
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{"use GET or POST"})
+	}
+}
+
+func (s *Service) submit(req jobRequest) (int, interface{}) {
+	s.mutex_.Lock()
+	defer s.mutex_.Unlock()
+
+	p := s.puzzles_[req.Puzzle]
+	if p == nil {
+		p = puzzles.Lookup(req.Puzzle)
+	}
+	if p == nil {
+		return http.StatusNotFound, errorResponse{"unknown puzzle '" + req.Puzzle + "'"}
+	}
+
+	switch {
+	case req.Kind != SOLVE_JOB && req.Kind != ANALYZE_JOB:
+		return http.StatusBadRequest, errorResponse{"unknown job kind '" + req.Kind + "'"}
+	case req.Kind == SOLVE_JOB && p.Kind != puzzles.SBP_PUZZLE:
+		return http.StatusBadRequest, errorResponse{"only sliding blocks puzzles can be solved"}
+	case s.closed_:
+		return http.StatusServiceUnavailable, errorResponse{"the service is closed"}
+	}
+
+	j := &Job{
+		id_:        strconv.Itoa(s.lastJob_ + 1),
+		kind_:      req.Kind,
+		puzzle_:    p,
+		maxDepth_:  limit(req.MaxDepth, p.MaxDepth, s.maxDepth_),
+		maxStates_: limit(req.MaxStates, p.MaxStates, s.maxStates_),
+		status_:    QUEUED,
+	}
+
+	if len(s.queue_) >= s.queueSize_ {
+		return http.StatusServiceUnavailable, errorResponse{"the job queue is full"}
+	}
+
+	s.lastJob_++
+	s.jobs_[j.id_] = j
+	s.jobsOrder_ = append(s.jobsOrder_, j)
+	s.queue_ = append(s.queue_, j)
+	s.wakeup_.Signal()
+	return http.StatusAccepted, j.report()
+}
+
+// Requested limit, else the puzzle one, capped by the service one
+func limit(requested int, puzzle int, max int) int {
+	x := requested
+	if x <= 0 {
+		x = puzzle
+	}
+	if x <= 0 || x > max {
+		x = max
+	}
+	return x
+}
+
+// Routes /jobs/{id}, /jobs/{id}/cancel and /jobs/{id}/result
+func (s *Service) handleJob(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/jobs/"), "/")
+	if len(parts) > 2 {
+		writeJSON(w, http.StatusNotFound, errorResponse{"not found"})
+		return
+	}
+	action := ""
+	if len(parts) == 2 {
+		action = parts[1]
+	}
+
+	s.mutex_.Lock()
+	defer s.mutex_.Unlock()
+
+	j := s.jobs_[parts[0]]
+	if j == nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{"unknown job '" + parts[0] + "'"})
+		return
+	}
+
+	switch {
+	case action == "" && r.Method == http.MethodGet:
+		writeJSON(w, http.StatusOK, j.report())
+
+	case action == "cancel" && r.Method == http.MethodPost:
+		s.cancel(j)
+		writeJSON(w, http.StatusOK, j.report())
+		s.evict()
+
+	case action == "result" && r.Method == http.MethodGet:
+		if j.status_ != DONE {
+			writeJSON(w, http.StatusConflict, j.report())
+			return
+		}
+		writeJSON(w, http.StatusOK, j.result_)
+
+	case action == "" || action == "cancel" || action == "result":
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{"method not allowed"})
+
+	default:
+		writeJSON(w, http.StatusNotFound, errorResponse{"not found"})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
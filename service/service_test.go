@@ -0,0 +1,262 @@
+package service
+
+import "bytes"
+import "encoding/json"
+import "net/http"
+import "net/http/httptest"
+import "testing"
+import "time"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+func postJSON(t *testing.T, url string, body interface{}, res interface{}) int {
+	data, _ := json.Marshal(body)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+func getJSON(t *testing.T, url string, res interface{}) int {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+// Polls the job until it is not queued or running
+func waitJob(t *testing.T, url string, id string) jobJSON {
+	var job jobJSON
+	for start := time.Now(); time.Since(start) < time.Minute; time.Sleep(10 * time.Millisecond) {
+		getJSON(t, url+"/jobs/"+id, &job)
+		if job.Status != QUEUED && job.Status != RUNNING {
+			return job
+		}
+	}
+	t.Fatalf("Job %s did not finish", id)
+	return job
+}
+
+// Polls the job until it is running
+func waitRunning(t *testing.T, url string, id string) {
+	var job jobJSON
+	for start := time.Now(); time.Since(start) < time.Minute; time.Sleep(time.Millisecond) {
+		getJSON(t, url+"/jobs/"+id, &job)
+		if job.Status == RUNNING {
+			return
+		}
+	}
+	t.Fatalf("Job %s did not start", id)
+}
+
+func TestSolveCatalog(t *testing.T) {
+	s := NewService(2, 4)
+	defer s.Close()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	var job jobJSON
+	if status := postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: "Pennant", Kind: SOLVE_JOB}, &job); status != http.StatusAccepted {
+		t.Fatalf("Job not accepted: %d", status)
+	}
+
+	job = waitJob(t, ts.URL, job.ID)
+	if job.Status != DONE || job.Explored == 0 {
+		t.Fatalf("Unexpected job report: %+v", job)
+	}
+
+	var res resultJSON
+	if status := getJSON(t, ts.URL+"/jobs/"+job.ID+"/result", &res); status != http.StatusOK {
+		t.Fatalf("No result: %d", status)
+	}
+	if res.Solve == nil || !res.Solve.Found || res.Solve.Moves != 59 || res.Solve.Metric != "move" || len(res.Solve.Steps) < 59 {
+		t.Errorf("Unexpected solution: %+v", res.Solve)
+	}
+}
+
+func TestSubmitPuzzle(t *testing.T) {
+	s := NewService(1, 4)
+	defer s.Close()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	// Piece 1 goes to the bottom right corner
+	def := puzzleRequest{
+		Start: grids.Matrix2d{
+			[]int{1, 2, 0},
+			[]int{3, 3, 0},
+		},
+		Goal: grids.Matrix2d{
+			[]int{0, 0, 0},
+			[]int{0, 0, 1},
+		},
+	}
+	var p puzzleResponse
+	if status := postJSON(t, ts.URL+"/puzzles", def, &p); status != http.StatusCreated || p.ID == "" {
+		t.Fatalf("Puzzle not created: %d", status)
+	}
+
+	var solveJob, analyzeJob jobJSON
+	postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: p.ID, Kind: SOLVE_JOB}, &solveJob)
+	postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: p.ID, Kind: ANALYZE_JOB}, &analyzeJob)
+
+	var res resultJSON
+	waitJob(t, ts.URL, solveJob.ID)
+	getJSON(t, ts.URL+"/jobs/"+solveJob.ID+"/result", &res)
+	if res.Solve == nil || !res.Solve.Found {
+		t.Errorf("Submitted puzzle not solved: %+v", res)
+	}
+
+	res = resultJSON{}
+	waitJob(t, ts.URL, analyzeJob.ID)
+	getJSON(t, ts.URL+"/jobs/"+analyzeJob.ID+"/result", &res)
+	if res.Analysis == nil || res.Analysis.Distance == 0 || len(res.Analysis.Farthest) == 0 {
+		t.Errorf("Unexpected analysis: %+v", res.Analysis)
+	}
+
+	// In step metric, each step counts
+	def.Metric = "step"
+	postJSON(t, ts.URL+"/puzzles", def, &p)
+	postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: p.ID, Kind: SOLVE_JOB}, &solveJob)
+	res = resultJSON{}
+	waitJob(t, ts.URL, solveJob.ID)
+	getJSON(t, ts.URL+"/jobs/"+solveJob.ID+"/result", &res)
+	if res.Solve == nil || !res.Solve.Found || res.Solve.Metric != "step" || res.Solve.Moves != len(res.Solve.Steps) {
+		t.Errorf("Unexpected step metric solution: %+v", res.Solve)
+	}
+
+	// Text definitions
+	text := puzzleRequest{Format: "ascii", StartText: "ab.\ncc.", GoalText: "...\n..a"}
+	if status := postJSON(t, ts.URL+"/puzzles", text, &p); status != http.StatusCreated {
+		t.Errorf("ASCII puzzle not created: %d", status)
+	}
+	bad := puzzleRequest{Format: "ascii", StartText: "ab.\ncc.", GoalText: "..\n.a"}
+	var e errorResponse
+	if status := postJSON(t, ts.URL+"/puzzles", bad, &e); status != http.StatusBadRequest || e.Error == "" {
+		t.Errorf("Inconsistent puzzle accepted: %d", status)
+	}
+}
+
+func TestAnalyzeEngel(t *testing.T) {
+	s := NewService(1, 1)
+	defer s.Close()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	var job jobJSON
+	postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: "SunMoon", Kind: ANALYZE_JOB, MaxDepth: 4}, &job)
+	if job = waitJob(t, ts.URL, job.ID); job.Status != DONE {
+		t.Fatalf("Unexpected job report: %+v", job)
+	}
+
+	var res resultJSON
+	getJSON(t, ts.URL+"/jobs/"+job.ID+"/result", &res)
+	if res.Analysis == nil || res.Analysis.Depths[0] != 1 || res.Analysis.Distance < 4 || res.States == 0 {
+		t.Errorf("Unexpected analysis: %+v", res.Analysis)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	s := NewService(1, 1)
+	defer s.Close()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	// A long search keeps the worker busy, the next job waits
+	var running, queued, rejected jobJSON
+	postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: "ColorWheels", Kind: ANALYZE_JOB}, &running)
+	waitRunning(t, ts.URL, running.ID)
+
+	if status := postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: "Pennant", Kind: SOLVE_JOB}, &queued); status != http.StatusAccepted || queued.Status != QUEUED {
+		t.Fatalf("Job not queued: %d %+v", status, queued)
+	}
+	if status := postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: "Pennant", Kind: SOLVE_JOB}, &rejected); status != http.StatusServiceUnavailable {
+		t.Errorf("Job accepted with a full queue: %d", status)
+	}
+
+	var res resultJSON
+	if status := getJSON(t, ts.URL+"/jobs/"+running.ID+"/result", &res); status != http.StatusConflict {
+		t.Errorf("Result of a running job: %d", status)
+	}
+
+	postJSON(t, ts.URL+"/jobs/"+queued.ID+"/cancel", nil, &queued)
+	if queued.Status != CANCELLED {
+		t.Errorf("Queued job not cancelled: %+v", queued)
+	}
+
+	// The cancelled job frees its queue slot, while the worker is still busy
+	if status := postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: "Pennant", Kind: SOLVE_JOB}, &queued); status != http.StatusAccepted || queued.Status != QUEUED {
+		t.Errorf("Job rejected after a cancellation: %d %+v", status, queued)
+	}
+	postJSON(t, ts.URL+"/jobs/"+queued.ID+"/cancel", nil, &queued)
+
+	postJSON(t, ts.URL+"/jobs/"+running.ID+"/cancel", nil, &running)
+	if running = waitJob(t, ts.URL, running.ID); running.Status != CANCELLED {
+		t.Errorf("Running job not cancelled: %+v", running)
+	}
+}
+
+func TestEviction(t *testing.T) {
+	s := NewService(1, 4)
+	s.SetMaxFinished(1)
+	defer s.Close()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	var first, second jobJSON
+	postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: "SunMoon", Kind: ANALYZE_JOB, MaxDepth: 4}, &first)
+	waitJob(t, ts.URL, first.ID)
+	postJSON(t, ts.URL+"/jobs", jobRequest{Puzzle: "SunMoon", Kind: ANALYZE_JOB, MaxDepth: 4}, &second)
+	waitJob(t, ts.URL, second.ID)
+
+	// Only the last finished job is kept
+	var e errorResponse
+	if status := getJSON(t, ts.URL+"/jobs/"+first.ID, &e); status != http.StatusNotFound {
+		t.Errorf("Old job kept: status %d", status)
+	}
+	var res resultJSON
+	if status := getJSON(t, ts.URL+"/jobs/"+second.ID+"/result", &res); status != http.StatusOK {
+		t.Errorf("Last job forgotten: status %d", status)
+	}
+	var list []jobJSON
+	if getJSON(t, ts.URL+"/jobs", &list); len(list) != 1 || list[0].ID != second.ID {
+		t.Errorf("Unexpected jobs list: %+v", list)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := NewService(1, 1)
+	defer s.Close()
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	cases := []struct {
+		req    jobRequest
+		status int
+	}{
+		{jobRequest{Puzzle: "Unknown", Kind: SOLVE_JOB}, http.StatusNotFound},
+		{jobRequest{Puzzle: "Pennant", Kind: "play"}, http.StatusBadRequest},
+		{jobRequest{Puzzle: "SunMoon", Kind: SOLVE_JOB}, http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		var e errorResponse
+		if status := postJSON(t, ts.URL+"/jobs", c.req, &e); status != c.status || e.Error == "" {
+			t.Errorf("%+v: status %d, expected %d", c.req, status, c.status)
+		}
+	}
+
+	var e errorResponse
+	if status := getJSON(t, ts.URL+"/jobs/42", &e); status != http.StatusNotFound {
+		t.Errorf("Unknown job: status %d", status)
+	}
+}
@@ -0,0 +1,82 @@
+package main
+
+import "flag"
+import "fmt"
+import "image/png"
+import "os"
+import "path/filepath"
+import "strings"
+import "time"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/checks"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/definitions"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/render"
+
+var (
+	showFlag  = flag.String("show", "", "solves this puzzle and draws the solution step by step")
+	delayFlag = flag.Duration("delay", 300*time.Millisecond, "with -show, time between steps (0 prints all the steps, one after the other)")
+	asciiFlag = flag.Bool("ascii", false, "with -show or -farthest, draws boards with plain ASCII characters")
+	colorFlag = flag.Bool("color", true, "with -show or -farthest, fills each piece with a color")
+	imageFlag = flag.String("image", "", "with -show, writes the solution to this file: a filmstrip (.svg, .png) or an animation (.gif)")
+)
+
+// Boards per row of filmstrips
+const FILMSTRIP_COLUMNS = 8
+
+// Writes the boards after each move of the solution, in the format given by the file extension
+func writeSolutionImage(path string, p *puzzles.Puzzle, solution []defs.Command) (err error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext != ".svg" && ext != ".png" && ext != ".gif" {
+		return fmt.Errorf("unknown image format %q (use .svg, .png or .gif)", filepath.Ext(path))
+	}
+
+	frames := render.MoveFrames(&p.Start, solution)
+	style := render.DefaultImageStyle()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	switch ext {
+	case ".svg":
+		_, err = f.WriteString(render.FilmstripSVG(frames, style, FILMSTRIP_COLUMNS))
+	case ".png":
+		err = png.Encode(f, render.FilmstripImage(frames, style, FILMSTRIP_COLUMNS))
+	case ".gif":
+		err = render.BoardsGIF(f, frames, style, *delayFlag)
+	}
+	return err
+}
+
+// Solves a puzzle of the catalog and animates its solution in the terminal
+func showSolution(name string) {
+	p := puzzles.Lookup(name)
+	if p == nil || p.Kind != puzzles.SBP_PUZZLE {
+		fmt.Fprintf(os.Stderr, "Unknown sliding blocks puzzle: %s\n", name)
+		os.Exit(1)
+	}
+
+	opts := render.Options{ASCII: *asciiFlag, Color: *colorFlag, Lattice: p.Lattice}
+
+	fmt.Printf("Solving %s...\n%s", p.Name, render.Board(&p.Start, opts))
+	r := checks.RunCase(p, checks.BenchmarkLimits{MaxDepth: *maxDepthFlag, MaxStates: *maxStatesFlag}, true)
+	if !r.Found {
+		fmt.Printf("Not solved: %s\n", r.EndStatus)
+		return
+	}
+
+	if *imageFlag != "" {
+		if err := writeSolutionImage(*imageFlag, p, r.Solution); err != nil {
+			fmt.Fprintf(os.Stderr, "Cannot write image: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s solved in %d moves, written to %s\n", p.Name, r.Length, *imageFlag)
+		return
+	}
+
+	render.Animate(os.Stdout, &p.Start, r.Solution, *delayFlag, *delayFlag > 0, opts)
+	fmt.Printf("\n%s solved in %d moves (%d steps)\n", p.Name, r.Length, len(r.Solution))
+}
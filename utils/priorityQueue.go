@@ -0,0 +1,189 @@
+package utils
+
+// Queue of values popped by increasing priority, whose priorities can be lowered while they wait
+type PriorityQueue[T any] interface {
+	// Adds the value, returning its handle
+	Push(v T, priority int) *PQItem[T]
+
+	// Removes and returns the value with the lowest priority, and its priority
+	Pop() (T, int)
+
+	// Lowers the priority of a waiting value
+	DecreaseKey(item *PQItem[T], priority int)
+
+	Size() int
+}
+
+// Handle of a value in a priority queue
+type PQItem[T any] struct {
+	value_    T
+	priority_ int
+
+	// Position in the queue, -1 once popped
+	index_ int
+}
+
+func (it *PQItem[T]) Value() T {
+	return it.value_
+}
+func (it *PQItem[T]) Priority() int {
+	return it.priority_
+}
+
+// True while the value is in the queue
+func (it *PQItem[T]) Waiting() bool {
+	return it.index_ >= 0
+}
+
+// Binary min-heap. Push, Pop and DecreaseKey take O(log n).
+type BinaryHeap[T any] struct {
+	items_ []*PQItem[T]
+}
+
+func (h *BinaryHeap[T]) Push(v T, priority int) *PQItem[T] {
+	it := &PQItem[T]{v, priority, len(h.items_)}
+	h.items_ = append(h.items_, it)
+	h.up(it.index_)
+	return it
+}
+
+func (h *BinaryHeap[T]) Pop() (T, int) {
+	if len(h.items_) == 0 {
+		panic("[BinaryHeap::Pop] empty queue!")
+	}
+
+	it := h.items_[0]
+	last := len(h.items_) - 1
+	h.swap(0, last)
+	h.items_[last] = nil
+	h.items_ = h.items_[:last]
+	h.down(0)
+
+	it.index_ = -1
+	return it.value_, it.priority_
+}
+
+func (h *BinaryHeap[T]) DecreaseKey(it *PQItem[T], priority int) {
+	if !it.Waiting() || h.items_[it.index_] != it {
+		panic("[BinaryHeap::DecreaseKey] item is not in the queue!")
+	}
+	if priority > it.priority_ {
+		panic("[BinaryHeap::DecreaseKey] priority increased!")
+	}
+	it.priority_ = priority
+	h.up(it.index_)
+}
+
+func (h *BinaryHeap[T]) Size() int {
+	return len(h.items_)
+}
+
+// Moves up the item at i while its parent has a higher priority
+func (h *BinaryHeap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if h.items_[parent].priority_ <= h.items_[i].priority_ {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// Moves down the item at i while a child has a lower priority
+func (h *BinaryHeap[T]) down(i int) {
+	n := len(h.items_)
+	for {
+		min := i
+		for _, child := range []int{2*i + 1, 2*i + 2} {
+			if child < n && h.items_[child].priority_ < h.items_[min].priority_ {
+				min = child
+			}
+		}
+		if min == i {
+			return
+		}
+		h.swap(i, min)
+		i = min
+	}
+}
+
+func (h *BinaryHeap[T]) swap(i int, j int) {
+	h.items_[i], h.items_[j] = h.items_[j], h.items_[i]
+	h.items_[i].index_ = i
+	h.items_[j].index_ = j
+}
+
+// Monotone bucket queue, for small non negative integer priorities: one bucket per priority. Values
+// can't be pushed (or decreased) below the priority of the last popped one, as in Dijkstra searches.
+// Push and DecreaseKey take O(1), and pops take O(1) amortized over the range of priorities.
+type BucketQueue[T any] struct {
+	buckets_ [][]*PQItem[T]
+
+	// Priority of the last popped value: lower buckets are empty
+	cur_  int
+	size_ int
+}
+
+func (q *BucketQueue[T]) Push(v T, priority int) *PQItem[T] {
+	it := &PQItem[T]{value_: v}
+	q.insert(it, priority)
+	q.size_++
+	return it
+}
+
+// Pops the values of a priority from the last pushed one
+func (q *BucketQueue[T]) Pop() (T, int) {
+	if q.size_ == 0 {
+		panic("[BucketQueue::Pop] empty queue!")
+	}
+	for len(q.buckets_[q.cur_]) == 0 {
+		q.buckets_[q.cur_] = nil
+		q.cur_++
+	}
+
+	bucket := q.buckets_[q.cur_]
+	it := bucket[len(bucket)-1]
+	bucket[len(bucket)-1] = nil
+	q.buckets_[q.cur_] = bucket[:len(bucket)-1]
+	q.size_--
+
+	it.index_ = -1
+	return it.value_, it.priority_
+}
+
+func (q *BucketQueue[T]) DecreaseKey(it *PQItem[T], priority int) {
+	if !it.Waiting() || it.index_ >= len(q.buckets_[it.priority_]) || q.buckets_[it.priority_][it.index_] != it {
+		panic("[BucketQueue::DecreaseKey] item is not in the queue!")
+	}
+	if priority > it.priority_ {
+		panic("[BucketQueue::DecreaseKey] priority increased!")
+	}
+
+	// Take it out of its bucket, filling the gap with the last one
+	bucket := q.buckets_[it.priority_]
+	last := bucket[len(bucket)-1]
+	bucket[it.index_] = last
+	last.index_ = it.index_
+	bucket[len(bucket)-1] = nil
+	q.buckets_[it.priority_] = bucket[:len(bucket)-1]
+
+	q.insert(it, priority)
+}
+
+func (q *BucketQueue[T]) Size() int {
+	return q.size_
+}
+
+// Adds the item to the bucket of the priority
+func (q *BucketQueue[T]) insert(it *PQItem[T], priority int) {
+	if priority < q.cur_ {
+		panic("[BucketQueue::insert] priority lower than the last popped one!")
+	}
+	for len(q.buckets_) <= priority {
+		q.buckets_ = append(q.buckets_, nil)
+	}
+	it.priority_ = priority
+	it.index_ = len(q.buckets_[priority])
+	q.buckets_[priority] = append(q.buckets_[priority], it)
+}
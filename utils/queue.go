@@ -2,27 +2,21 @@ package utils
 
 import "fmt"
 
-type T interface{}
-
-type PriorityQueue interface {
-	PushBack(T)
-	PopFront() T
-}
-
-type node struct {
-	prev_  *node
-	next_  *node
+type node[T any] struct {
+	prev_  *node[T]
+	next_  *node[T]
 	value_ T
 }
 
-type Queue struct {
-	sttNode_ *node
-	endNode_ *node
+// FIFO queue of values of type T
+type Queue[T any] struct {
+	sttNode_ *node[T]
+	endNode_ *node[T]
 	size_    int
 }
 
-func (q *Queue) PushBack(v T) {
-	n := &node{q.endNode_, nil, v}
+func (q *Queue[T]) PushBack(v T) {
+	n := &node[T]{q.endNode_, nil, v}
 
 	if q.sttNode_ == nil {
 		q.sttNode_ = n
@@ -37,7 +31,8 @@ func (q *Queue) PushBack(v T) {
 	q.size_++
 }
 
-func (q *Queue) PopFront() T {
+// Removes and returns the first value. Returns the zero value if the queue is empty.
+func (q *Queue[T]) PopFront() T {
 	if q.sttNode_ != nil {
 		s := q.sttNode_.value_
 
@@ -50,17 +45,16 @@ func (q *Queue) PopFront() T {
 		if q.sttNode_ == nil {
 			q.endNode_ = nil
 		}
-		if s != nil {
-			q.size_--
-		}
+		q.size_--
 		return s
 	}
-	return nil
+	var zero T
+	return zero
 }
-func (q *Queue) Size() int {
+func (q *Queue[T]) Size() int {
 	return q.size_
 }
-func (q *Queue) Print() {
+func (q *Queue[T]) Print() {
 	fmt.Printf("<")
 
 	if q.sttNode_ != nil {
@@ -70,7 +64,7 @@ func (q *Queue) Print() {
 	fmt.Printf(">")
 }
 
-func (q *Queue) subPrint(x *node) {
+func (q *Queue[T]) subPrint(x *node[T]) {
 	fmt.Printf("%v ", x.value_)
 
 	if x.next_ != nil {
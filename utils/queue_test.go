@@ -0,0 +1,100 @@
+package utils
+
+import "math/rand"
+import "sort"
+import "testing"
+
+func TestQueue(t *testing.T) {
+	var q Queue[string]
+	if q.PopFront() != "" || q.Size() != 0 {
+		t.Errorf("Empty queue pops %q, size %d", q.PopFront(), q.Size())
+	}
+
+	for _, s := range []string{"a", "b", "c"} {
+		q.PushBack(s)
+	}
+	if q.Size() != 3 {
+		t.Errorf("Size %d, expected 3", q.Size())
+	}
+	for _, s := range []string{"a", "b", "c"} {
+		if x := q.PopFront(); x != s {
+			t.Errorf("Popped %q, expected %q", x, s)
+		}
+	}
+	if q.Size() != 0 {
+		t.Errorf("Size %d after popping all", q.Size())
+	}
+
+	q.PushBack("d")
+	if x := q.PopFront(); x != "d" || q.Size() != 0 {
+		t.Errorf("Popped %q, size %d after reuse", x, q.Size())
+	}
+}
+
+// Both priority queues pop values by increasing priority, with decreased keys.
+func TestPriorityQueues(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		q    PriorityQueue[int]
+	}{
+		{"binary heap", &BinaryHeap[int]{}},
+		{"bucket queue", &BucketQueue[int]{}},
+	} {
+		r := rand.New(rand.NewSource(1))
+
+		// Values are their own final priority
+		var expected []int
+		var items []*PQItem[int]
+		for i := 0; i < 200; i++ {
+			v := r.Intn(50)
+			expected = append(expected, v)
+			items = append(items, c.q.Push(v, v+r.Intn(20)))
+		}
+		for _, it := range items {
+			c.q.DecreaseKey(it, it.Value())
+		}
+		sort.Ints(expected)
+
+		if c.q.Size() != len(expected) {
+			t.Errorf("%s: size %d, expected %d", c.name, c.q.Size(), len(expected))
+		}
+		for i, e := range expected {
+			v, p := c.q.Pop()
+			if v != e || p != e {
+				t.Errorf("%s: pop %d gives value %d, priority %d, expected %d", c.name, i, v, p, e)
+				break
+			}
+		}
+		if c.q.Size() != 0 || items[0].Waiting() {
+			t.Errorf("%s: size %d after popping all", c.name, c.q.Size())
+		}
+	}
+}
+
+// Dijkstra-like use: pushes and decreases above the last popped priority.
+func TestBucketQueueMonotone(t *testing.T) {
+	var q BucketQueue[string]
+	a := q.Push("a", 3)
+	q.Push("b", 1)
+
+	if v, p := q.Pop(); v != "b" || p != 1 {
+		t.Errorf("Popped %q at %d, expected b at 1", v, p)
+	}
+	q.Push("c", 2)
+	q.DecreaseKey(a, 1)
+	if a.Priority() != 1 {
+		t.Errorf("Decreased priority %d, expected 1", a.Priority())
+	}
+	for _, e := range []string{"a", "c"} {
+		if v, _ := q.Pop(); v != e {
+			t.Errorf("Popped %q, expected %q", v, e)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Push below the last popped priority does not panic")
+		}
+	}()
+	q.Push("d", 0)
+}
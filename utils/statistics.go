@@ -21,6 +21,9 @@ func (s *ScalarStatistic) Add(x int) {
 	s.total_ += x
 	s.count_++
 }
+func (s *ScalarStatistic) Total() int {
+	return s.total_
+}
 func (s *ScalarStatistic) Resume(out *color.Color) {
 	out.Printf("\n %s: %d", s.name_, s.total_)
 }
@@ -84,6 +87,15 @@ func (s *RangeHistogram) Add(x int, c int) {
 	s.data_[x] += c
 }
 
+// Copy of the counts by value
+func (s *RangeHistogram) Data() map[int]int {
+	data := make(map[int]int, len(s.data_))
+	for k, v := range s.data_ {
+		data[k] = v
+	}
+	return data
+}
+
 func (s *RangeHistogram) ResumeHistogram(out *color.Color) {
 	out.Printf("\n %s:", s.name_)
 
@@ -0,0 +1,268 @@
+package web
+
+import "embed"
+import "encoding/json"
+import "fmt"
+import "io/fs"
+import "net/http"
+import "sync"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/checks"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/formats"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/games"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/puzzles"
+
+// Solver limits for web requests
+const (
+	MAX_DEPTH  = 300
+	MAX_STATES = 2000000
+)
+
+//go:embed static
+var s_static_ embed.FS
+
+// Local web interface: a page to edit, play and solve sliding blocks puzzles, and its JSON API.
+// Everything is served from the binary, so it works offline.
+type Server struct {
+
+	// Searches are memory hungry: solves are served one at a time. Moves build their own game and
+	// never wait for them.
+	mutex_ sync.Mutex
+
+	mux_ *http.ServeMux
+}
+
+func NewServer() *Server {
+	s := &Server{mux_: http.NewServeMux()}
+
+	s.mux_.Handle("/", http.FileServer(http.FS(staticFS())))
+	s.mux_.HandleFunc("/api/puzzles", s.handlePuzzles)
+	s.mux_.HandleFunc("/api/move", s.handleMove)
+	s.mux_.HandleFunc("/api/solve", s.handleSolve)
+
+	return s
+}
+
+// Implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux_.ServeHTTP(w, r)
+}
+
+// Serves the interface at addr (e.g. "localhost:8080") until it fails.
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s)
+}
+
+// A puzzle of the catalog, as sent to the page
+type puzzleJSON struct {
+	Name    string         `json:"name"`
+	Author  string         `json:"author"`
+	Source  string         `json:"source"`
+	Optimal int            `json:"optimal"`
+	Start   grids.Matrix2d `json:"start"`
+	Goal    grids.Matrix2d `json:"goal"`
+	Fixed   []int          `json:"fixed"`
+}
+
+// Board sent by the page: to move a piece of it, or to solve it
+type boardRequest struct {
+	Board grids.Matrix2d `json:"board"`
+	Goal  grids.Matrix2d `json:"goal"`
+	Fixed []int          `json:"fixed"`
+
+	// Move
+	Piece int `json:"piece"`
+	DRow  int `json:"dRow"`
+	DCol  int `json:"dCol"`
+
+	// Solve
+	MaxStates int `json:"maxStates"`
+}
+
+type moveResponse struct {
+	Valid  bool           `json:"valid"`
+	Board  grids.Matrix2d `json:"board"`
+	Solved bool           `json:"solved"`
+
+	// One cell steps of the move
+	Steps [][3]int `json:"steps,omitempty"`
+}
+
+type solveResponse struct {
+	Found  bool     `json:"found"`
+	Moves  int      `json:"moves"`
+	Metric string   `json:"metric"`
+	Steps  [][3]int `json:"steps"`
+	States int      `json:"states"`
+	Status string   `json:"status"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s *Server) handlePuzzles(w http.ResponseWriter, r *http.Request) {
+	var res []puzzleJSON
+	for _, p := range puzzles.ByKind(puzzles.SBP_PUZZLE, true) {
+		res = append(res, puzzleJSON{p.Name, p.Author, p.Source, p.Optimal, p.Start, p.Goal, p.Fixed})
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// Moves a piece to a position it can reach without moving the other pieces
+func (s *Server) handleMove(w http.ResponseWriter, r *http.Request) {
+	req, ok := readBoardRequest(w, r)
+	if !ok {
+		return
+	}
+
+	res := moveResponse{Board: req.Board}
+	err := protect(func() {
+		g := &games.SBGame{}
+		g.Define(&req.Board)
+		for _, id := range req.Fixed {
+			g.SetFixedPiece(id)
+		}
+		g.Build()
+
+		for _, m := range g.CompoundMovements() {
+			cMov := m.(*grids.CompoundMov2)
+			dRow, dCol := cMov.Translation()
+			if m.PieceId() == req.Piece && dRow == req.DRow && dCol == req.DCol {
+				g.Move(m)
+				res.Valid = true
+				res.Board = *g.State().(*games.SBPState).Grid()
+				for _, step := range cMov.Steps() {
+					sRow, sCol := step.Translation()
+					res.Steps = append(res.Steps, [3]int{step.PieceId(), sRow, sCol})
+				}
+				break
+			}
+		}
+	})
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+
+	res.Solved = reachesGoal(res.Board, req.Goal)
+	writeJSON(w, http.StatusOK, res)
+}
+
+// Solves the board with the sliding blocks finder
+func (s *Server) handleSolve(w http.ResponseWriter, r *http.Request) {
+	req, ok := readBoardRequest(w, r)
+	if !ok {
+		return
+	}
+
+	p, err := formats.NewSBPPuzzle(req.Board, req.Goal, req.Fixed)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+
+	maxStates := req.MaxStates
+	if maxStates <= 0 || maxStates > MAX_STATES {
+		maxStates = MAX_STATES
+	}
+
+	s.mutex_.Lock()
+	defer s.mutex_.Unlock()
+
+	var result checks.BenchmarkResult
+	err = protect(func() {
+		result = checks.RunCase(p, checks.BenchmarkLimits{MaxDepth: MAX_DEPTH, MaxStates: maxStates}, true)
+	})
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return
+	}
+
+	res := solveResponse{Found: result.Found, Moves: result.Length, Metric: result.Metric, States: result.States, Status: result.EndStatus}
+	for _, c := range result.Solution {
+		dRow, dCol := c.(*grids.GridMov2).Translation()
+		res.Steps = append(res.Steps, [3]int{c.PieceId(), dRow, dCol})
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func readBoardRequest(w http.ResponseWriter, r *http.Request) (req boardRequest, ok bool) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{"use POST"})
+		return req, false
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{"invalid request: " + err.Error()})
+		return req, false
+	}
+	if err := checkBoard(req.Board); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{err.Error()})
+		return req, false
+	}
+	return req, true
+}
+
+// Boards must be rectangular, with non negative ids
+func checkBoard(m grids.Matrix2d) error {
+	if m.Rows() == 0 || m.Cols() == 0 {
+		return fmt.Errorf("empty board")
+	}
+	for _, row := range m {
+		if len(row) != m.Cols() {
+			return fmt.Errorf("board rows have different lengths")
+		}
+		for _, v := range row {
+			if v < 0 {
+				return fmt.Errorf("invalid piece id %d", v)
+			}
+		}
+	}
+	return nil
+}
+
+// Non-zero goal cells must hold the same piece on the board
+func reachesGoal(board grids.Matrix2d, goal grids.Matrix2d) bool {
+	if goal.Rows() != board.Rows() || goal.Cols() != board.Cols() {
+		return false
+	}
+	found := false
+	for r := range goal {
+		for c, v := range goal[r] {
+			if v != 0 {
+				if board[r][c] != v {
+					return false
+				}
+				found = true
+			}
+		}
+	}
+	return found
+}
+
+// Games panic on inconsistent boards: turn it into an error
+func protect(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid board: %v", r)
+		}
+	}()
+	f()
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// Files of the page, at the root of the site
+func staticFS() fs.FS {
+	sub, err := fs.Sub(s_static_, "static")
+	if err != nil {
+		panic("[web::staticFS] " + err.Error())
+	}
+	return sub
+}
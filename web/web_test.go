@@ -0,0 +1,144 @@
+package web
+
+import "bytes"
+import "encoding/json"
+import "io"
+import "net/http"
+import "net/http/httptest"
+import "strings"
+import "testing"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/grids"
+
+func postJSON(t *testing.T, url string, body interface{}, res interface{}) int {
+	data, _ := json.Marshal(body)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(res); err != nil {
+		t.Fatal(err)
+	}
+	return resp.StatusCode
+}
+
+func TestPage(t *testing.T) {
+	ts := httptest.NewServer(NewServer())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(page), "/api/solve") {
+		t.Error("Page not served")
+	}
+
+	var list []puzzleJSON
+	resp, err = http.Get(ts.URL + "/api/puzzles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	json.NewDecoder(resp.Body).Decode(&list)
+	resp.Body.Close()
+
+	found := false
+	for _, p := range list {
+		if p.Name == "Pennant" && p.Optimal == 59 && p.Start.Rows() == 5 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Pennant not in the puzzles list")
+	}
+}
+
+func TestMove(t *testing.T) {
+	server := NewServer()
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	// Moves are served while a solve is running
+	server.mutex_.Lock()
+	defer server.mutex_.Unlock()
+
+	board := grids.Matrix2d{
+		[]int{1, 0},
+		[]int{2, 0},
+	}
+	goal := grids.Matrix2d{
+		[]int{0, 1},
+		[]int{0, 0},
+	}
+
+	var res moveResponse
+	postJSON(t, ts.URL+"/api/move", boardRequest{Board: board, Goal: goal, Piece: 1, DRow: 0, DCol: 1}, &res)
+	if !res.Valid || !res.Solved || res.Board.At(0, 1) != 1 || res.Board.At(0, 0) != 0 {
+		t.Errorf("Valid move not applied: %+v", res)
+	}
+
+	// Around piece 2, in two steps
+	res = moveResponse{}
+	postJSON(t, ts.URL+"/api/move", boardRequest{Board: board, Goal: goal, Piece: 1, DRow: 1, DCol: 1}, &res)
+	if !res.Valid || res.Board.At(1, 1) != 1 || len(res.Steps) != 2 {
+		t.Errorf("Compound move not applied: %+v", res)
+	}
+
+	// Blocked by the board border, and by a fixed piece
+	res = moveResponse{}
+	postJSON(t, ts.URL+"/api/move", boardRequest{Board: board, Goal: goal, Piece: 1, DRow: -1, DCol: 0}, &res)
+	if res.Valid || res.Board.At(0, 0) != 1 {
+		t.Errorf("Invalid move applied: %+v", res)
+	}
+	res = moveResponse{}
+	postJSON(t, ts.URL+"/api/move", boardRequest{Board: board, Goal: goal, Fixed: []int{1}, Piece: 1, DRow: 0, DCol: 1}, &res)
+	if res.Valid {
+		t.Errorf("Fixed piece moved: %+v", res)
+	}
+
+	var e errorResponse
+	if code := postJSON(t, ts.URL+"/api/move", boardRequest{Board: grids.Matrix2d{[]int{1, 0}, []int{2}}}, &e); code != http.StatusBadRequest || e.Error == "" {
+		t.Errorf("Ragged board should be rejected: %d %+v", code, e)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	ts := httptest.NewServer(NewServer())
+	defer ts.Close()
+
+	board := grids.Matrix2d{
+		[]int{1, 2, 0},
+		[]int{3, 3, 0},
+	}
+	goal := grids.Matrix2d{
+		[]int{0, 0, 0},
+		[]int{0, 0, 1},
+	}
+
+	var res solveResponse
+	postJSON(t, ts.URL+"/api/solve", boardRequest{Board: board, Goal: goal}, &res)
+	if !res.Found || len(res.Steps) == 0 {
+		t.Fatalf("Not solved: %+v", res)
+	}
+
+	// Replay the steps with the move endpoint
+	for _, step := range res.Steps {
+		var m moveResponse
+		postJSON(t, ts.URL+"/api/move", boardRequest{Board: board, Goal: goal, Piece: step[0], DRow: step[1], DCol: step[2]}, &m)
+		if !m.Valid {
+			t.Fatalf("Invalid solution step %v on %v", step, board)
+		}
+		board = m.Board
+	}
+	if !reachesGoal(board, goal) {
+		t.Errorf("Solution does not reach the goal: %v", board)
+	}
+
+	var e errorResponse
+	if code := postJSON(t, ts.URL+"/api/solve", boardRequest{Board: board, Goal: grids.Matrix2d{[]int{9, 0, 0}, []int{0, 0, 0}}}, &e); code != http.StatusBadRequest {
+		t.Errorf("Unknown goal piece should be rejected: %d %+v", code, e)
+	}
+}
@@ -0,0 +1,18 @@
+package main
+
+import "flag"
+import "fmt"
+import "os"
+
+import "github.com/edgarweto/puzzlopia/puzzle-solvers/web"
+
+var webFlag = flag.String("web", "", "serves the web interface at this address (e.g. localhost:8080)")
+
+// Serves the local web interface until it fails
+func serveWeb(addr string) {
+	fmt.Printf("Web interface at http://%s/\n", addr)
+	if err := web.NewServer().ListenAndServe(addr); err != nil {
+		fmt.Fprintf(os.Stderr, "Web server stopped: %v\n", err)
+		os.Exit(1)
+	}
+}